@@ -0,0 +1,521 @@
+// Package client is a client of EventReplicatorService spreading requests
+// over several nodes. It prefers the nodes of its own region, skips the
+// nodes that stopped answering for a while and retries transient failures
+// on the next node, then again with an exponential backoff.
+//
+//	c, err := client.New([]string{"10.0.1.5:9000", "10.0.2.5:9000"},
+//		client.WithRegion(1), client.WithRefreshInterval(time.Minute))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//	ev, err := c.Get(ctx, &rpc.GetEventRequest{Id: "k"})
+//
+// Client implements rpc.EventReplicatorService. Errors returned by a node
+// are returned as is, as twirp.Error values; only unreachable nodes and
+// Unavailable errors are retried. Put and Delete may then be applied more
+// than once, each time as a new version of the event.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	defaultAttempts   = 3
+	defaultBackoff    = 100 * time.Millisecond
+	defaultMaxBackoff = 2 * time.Second
+	defaultCooldown   = 5 * time.Second
+	defaultTimeout    = 10 * time.Second
+
+	// pathPrefix is where nodes serve the API.
+	pathPrefix = "/rz"
+
+	// refreshTimeout bounds a background refresh of the endpoints.
+	refreshTimeout = 5 * time.Second
+)
+
+// Option customizes a Client created by New.
+type Option func(*Client)
+
+// WithRegion makes the client send requests to the nodes of region first,
+// once their region is known from Refresh.
+func WithRegion(region uint) Option {
+	return func(c *Client) {
+		c.region = region
+	}
+}
+
+// WithHTTPClient sets the HTTP client requests go through. Defaults to an
+// http.Client timing requests out after 10s.
+func WithHTTPClient(hc rpc.HTTPClient) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// WithRetry sets how many times a request goes through every endpoint,
+// waiting backoff after the first round and doubling the wait up to
+// maxBackoff. Defaults to 3 rounds from 100ms up to 2s.
+func WithRetry(attempts int, backoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		if attempts > 0 {
+			c.attempts = attempts
+		}
+		if backoff > 0 {
+			c.backoff = backoff
+		}
+		if maxBackoff > 0 {
+			c.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithCooldown sets how long an endpoint that failed is only tried once
+// the others failed as well. Defaults to 5s.
+func WithCooldown(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.cooldown = d
+		}
+	}
+}
+
+// WithRefreshInterval refreshes the endpoints from the cluster membership
+// right away and then every d, in the background until Close.
+func WithRefreshInterval(d time.Duration) Option {
+	return func(c *Client) {
+		c.refreshInterval = d
+	}
+}
+
+// Endpoint is a node the client sends requests to.
+type Endpoint struct {
+	URL string
+
+	// Region is the region of the node, zero until known.
+	Region uint
+
+	// Discovered is set on the endpoints learned from the membership
+	// rather than given to New.
+	Discovered bool
+
+	// Healthy is unset while the node is skipped after failing.
+	Healthy bool
+}
+
+type endpoint struct {
+	Endpoint
+	svc       rpc.EventReplicatorService
+	downUntil time.Time
+}
+
+// Client sends requests to the nodes of a cluster. It is safe for
+// concurrent use.
+type Client struct {
+	httpClient rpc.HTTPClient
+	region     uint
+
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	cooldown   time.Duration
+
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ rpc.EventReplicatorService = (*Client)(nil)
+
+// New returns a client of the nodes serving the API at endpoints, given as
+// host:port or as URLs.
+func New(endpoints []string, opts ...Option) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("client: no endpoint")
+	}
+	c := &Client{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		attempts:   defaultAttempts,
+		backoff:    defaultBackoff,
+		maxBackoff: defaultMaxBackoff,
+		cooldown:   defaultCooldown,
+		stop:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for _, e := range endpoints {
+		u, err := normalize(e)
+		if err != nil {
+			return nil, err
+		}
+		if c.find(u) == nil {
+			c.endpoints = append(c.endpoints, c.newEndpoint(u, false))
+		}
+	}
+	if c.refreshInterval > 0 {
+		go c.refreshLoop()
+	}
+	return c, nil
+}
+
+// normalize returns the base URL of an endpoint, http if no scheme is given.
+func normalize(endpoint string) (string, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("client: invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("client: invalid endpoint %q: no host", endpoint)
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+func (c *Client) newEndpoint(u string, discovered bool) *endpoint {
+	return &endpoint{
+		Endpoint: Endpoint{URL: u, Discovered: discovered, Healthy: true},
+		svc:      rpc.NewEventReplicatorServiceProtobufClient(u, c.httpClient, twirp.WithClientPathPrefix(pathPrefix)),
+	}
+}
+
+// find returns the endpoint at u, or nil. c.mu must be held if the client
+// is in use.
+func (c *Client) find(u string) *endpoint {
+	for _, ep := range c.endpoints {
+		if ep.URL == u {
+			return ep
+		}
+	}
+	return nil
+}
+
+// Close stops refreshing the endpoints in the background.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+// Endpoints returns the endpoints in the order the next request tries them.
+func (c *Client) Endpoints() []Endpoint {
+	ordered := c.order()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Endpoint, len(ordered))
+	for i, ep := range ordered {
+		out[i] = ep.Endpoint
+	}
+	return out
+}
+
+// order returns the endpoints to try: the healthy ones before the ones
+// cooling down, the ones of the local region first, then the ones of an
+// unknown region, each in the order they were added.
+func (c *Client) order() []*endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for _, ep := range c.endpoints {
+		ep.Healthy = !now.Before(ep.downUntil)
+	}
+	out := append([]*endpoint{}, c.endpoints...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Healthy != b.Healthy {
+			return a.Healthy
+		}
+		if !a.Healthy {
+			return a.downUntil.Before(b.downUntil)
+		}
+		return c.distance(a) < c.distance(b)
+	})
+	return out
+}
+
+// distance ranks an endpoint by region: the local one, unknown, others.
+func (c *Client) distance(ep *endpoint) int {
+	switch {
+	case c.region == 0 || ep.Region == c.region:
+		return 0
+	case ep.Region == 0:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (c *Client) succeeded(ep *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ep.downUntil = time.Time{}
+}
+
+func (c *Client) failed(ep *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ep.downUntil = time.Now().Add(c.cooldown)
+}
+
+// Pin returns a client sending every request to the first node answering,
+// e.g. to page through the events of a single node; requests fail rather
+// than move to another node. Close it once done.
+func (c *Client) Pin(ctx context.Context) (*Client, error) {
+	var pinned *endpoint
+	err := c.call(ctx, func(ep *endpoint) error {
+		_, err := ep.svc.GetRegions(ctx, &rpc.GetRegionsRequest{})
+		if err == nil {
+			pinned = ep
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	p := &Client{
+		httpClient: c.httpClient,
+		region:     c.region,
+		attempts:   c.attempts,
+		backoff:    c.backoff,
+		maxBackoff: c.maxBackoff,
+		cooldown:   c.cooldown,
+		stop:       make(chan struct{}),
+	}
+	c.mu.Lock()
+	p.endpoints = []*endpoint{{Endpoint: pinned.Endpoint, svc: pinned.svc}}
+	c.mu.Unlock()
+	return p, nil
+}
+
+// call runs fn against the endpoints in order until one answers, then
+// retries every endpoint after a backoff, up to c.attempts rounds. It
+// returns the last error.
+func (c *Client) call(ctx context.Context, fn func(ep *endpoint) error) error {
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		var err error
+		for _, ep := range c.order() {
+			err = fn(ep)
+			if ctx.Err() != nil {
+				return err
+			}
+			if err == nil || !transient(err) {
+				// the node answered, even if with an error
+				c.succeeded(ep)
+				return err
+			}
+			c.failed(ep)
+		}
+		if attempt >= c.attempts {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+// transient reports whether err may not happen on another try or another
+// node: the node could not be reached, or did not answer.
+func transient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var twerr twirp.Error
+	return errors.As(err, &twerr) && twerr.Code() == twirp.Unavailable
+}
+
+// Refresh asks a node for the members of the cluster. Members alive with an
+// API endpoint are added to the endpoints along with their region; the
+// discovered endpoints of members gone are removed.
+func (c *Client) Refresh(ctx context.Context) error {
+	var resp *rpc.ListMembersResponse
+	var from *endpoint
+	err := c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.ListMembers(ctx, &rpc.ListMembersRequest{})
+		from = ep
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	scheme := "http"
+	if u, err := url.Parse(from.URL); err == nil {
+		scheme = u.Scheme
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	alive := make(map[string]bool)
+	for _, m := range resp.Members {
+		if m.Local {
+			from.Region = uint(m.Region)
+		}
+		if m.State != "alive" || m.ApiEndpoint == "" {
+			continue
+		}
+		u := scheme + "://" + m.ApiEndpoint
+		alive[u] = true
+		ep := c.find(u)
+		if ep == nil {
+			if m.Local {
+				// the node answering, under another name
+				continue
+			}
+			ep = c.newEndpoint(u, true)
+			c.endpoints = append(c.endpoints, ep)
+		}
+		ep.Region = uint(m.Region)
+	}
+	kept := c.endpoints[:0]
+	for _, ep := range c.endpoints {
+		if !ep.Discovered || alive[ep.URL] {
+			kept = append(kept, ep)
+		}
+	}
+	c.endpoints = kept
+	return nil
+}
+
+func (c *Client) refreshLoop() {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		// failures leave the endpoints as they are until the next tick
+		c.Refresh(ctx)
+		cancel()
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Put writes an event through the nearest node available.
+func (c *Client) Put(ctx context.Context, req *rpc.PutEventRequest) (resp *rpc.Event, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.Put(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// Get reads an event from the nearest node available.
+func (c *Client) Get(ctx context.Context, req *rpc.GetEventRequest) (resp *rpc.Event, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.Get(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// Delete deletes an event through the nearest node available.
+func (c *Client) Delete(ctx context.Context, req *rpc.DeleteEventRequest) (resp *rpc.Event, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.Delete(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// ListEvents lists the events of the nearest node available. Page tokens
+// encode keys, so that pages can come from different nodes.
+func (c *Client) ListEvents(ctx context.Context, req *rpc.ListEventsRequest) (resp *rpc.ListEventsResponse, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.ListEvents(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// Resolve writes the value chosen for a conflicted event through the
+// nearest node available.
+func (c *Client) Resolve(ctx context.Context, req *rpc.ResolveEventRequest) (resp *rpc.Event, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.Resolve(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// GetReplicationStatus returns the replication status seen by the nearest
+// node available.
+func (c *Client) GetReplicationStatus(ctx context.Context, req *rpc.GetReplicationStatusRequest) (resp *rpc.ReplicationStatus, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.GetReplicationStatus(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// ListMembers returns the members seen by the nearest node available.
+func (c *Client) ListMembers(ctx context.Context, req *rpc.ListMembersRequest) (resp *rpc.ListMembersResponse, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.ListMembers(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// GetRegions returns the region registry of the nearest node available.
+func (c *Client) GetRegions(ctx context.Context, req *rpc.GetRegionsRequest) (resp *rpc.RegionRegistry, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.GetRegions(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// UpdateRegions updates the region registry through the nearest node
+// available.
+func (c *Client) UpdateRegions(ctx context.Context, req *rpc.UpdateRegionsRequest) (resp *rpc.RegionRegistry, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.UpdateRegions(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// ListQuarantined returns the bad gossip payloads kept by the nearest node
+// available.
+func (c *Client) ListQuarantined(ctx context.Context, req *rpc.ListQuarantinedRequest) (resp *rpc.ListQuarantinedResponse, err error) {
+	err = c.call(ctx, func(ep *endpoint) (err error) {
+		resp, err = ep.svc.ListQuarantined(ctx, req)
+		return err
+	})
+	return resp, err
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+// node serves the API of a node in region, failing its first requests with
+// fail.
+type node struct {
+	rpc.EventReplicatorService
+
+	region  int32
+	members []*rpc.Member
+
+	mu    sync.Mutex
+	calls int
+	fail  []error
+
+	srv *httptest.Server
+}
+
+func newNode(t *testing.T, region int32) *node {
+	n := &node{region: region}
+	n.srv = httptest.NewServer(rpc.NewEventReplicatorServiceServer(n, twirp.WithServerPathPrefix(pathPrefix)))
+	t.Cleanup(n.srv.Close)
+	return n
+}
+
+func (n *node) endpoint() string {
+	return strings.TrimPrefix(n.srv.URL, "http://")
+}
+
+// cluster makes every node list the nodes given as members.
+func cluster(nodes ...*node) {
+	for _, n := range nodes {
+		n.mu.Lock()
+		n.members = nil
+		for _, m := range nodes {
+			n.members = append(n.members, &rpc.Member{
+				Name:        m.endpoint(),
+				State:       "alive",
+				Region:      m.region,
+				ApiEndpoint: m.endpoint(),
+				Local:       m == n,
+			})
+		}
+		n.mu.Unlock()
+	}
+}
+
+func (n *node) Get(ctx context.Context, req *rpc.GetEventRequest) (*rpc.Event, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls++
+	if len(n.fail) > 0 {
+		err := n.fail[0]
+		n.fail = n.fail[1:]
+		return nil, err
+	}
+	return &rpc.Event{Id: req.Id, Data: n.endpoint()}, nil
+}
+
+func (n *node) ListMembers(ctx context.Context, req *rpc.ListMembersRequest) (*rpc.ListMembersResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &rpc.ListMembersResponse{Members: n.members}, nil
+}
+
+func (n *node) GetRegions(ctx context.Context, req *rpc.GetRegionsRequest) (*rpc.RegionRegistry, error) {
+	return &rpc.RegionRegistry{}, nil
+}
+
+func (n *node) Calls() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls
+}
+
+func fastRetry() Option {
+	return WithRetry(3, time.Millisecond, 5*time.Millisecond)
+}
+
+func TestFailover(t *testing.T) {
+	down := newNode(t, 1)
+	down.srv.Close()
+	up := newNode(t, 1)
+
+	c, err := New([]string{down.endpoint(), up.endpoint()}, fastRetry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev, err := c.Get(context.Background(), &rpc.GetEventRequest{Id: "k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Data != up.endpoint() {
+		t.Fatalf("answered by %s, want %s", ev.Data, up.endpoint())
+	}
+
+	eps := c.Endpoints()
+	if eps[0].URL != up.srv.URL || !eps[0].Healthy || eps[1].Healthy {
+		t.Fatalf("endpoints = %+v, want the node down last and unhealthy", eps)
+	}
+	c.Get(context.Background(), &rpc.GetEventRequest{Id: "k"})
+	if up.Calls() != 2 {
+		t.Fatalf("healthy node got %d calls, want 2", up.Calls())
+	}
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	n := newNode(t, 1)
+	n.fail = []error{twirp.NewError(twirp.Unavailable, "busy"), twirp.NewError(twirp.Unavailable, "busy")}
+
+	c, err := New([]string{n.endpoint()}, fastRetry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(context.Background(), &rpc.GetEventRequest{Id: "k"}); err != nil {
+		t.Fatal(err)
+	}
+	if n.Calls() != 3 {
+		t.Fatalf("%d calls, want 3", n.Calls())
+	}
+
+	n.fail = []error{twirp.NotFoundError("event not found")}
+	_, err = c.Get(context.Background(), &rpc.GetEventRequest{Id: "k"})
+	if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != twirp.NotFound {
+		t.Fatalf("err = %v, want not found", err)
+	}
+	if n.Calls() != 4 {
+		t.Fatalf("a not found error was retried: %d calls, want 4", n.Calls())
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	n := newNode(t, 1)
+	for i := 0; i < 5; i++ {
+		n.fail = append(n.fail, twirp.NewError(twirp.Unavailable, "busy"))
+	}
+
+	c, err := New([]string{n.endpoint()}, fastRetry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Get(context.Background(), &rpc.GetEventRequest{Id: "k"})
+	if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != twirp.Unavailable {
+		t.Fatalf("err = %v, want unavailable", err)
+	}
+	if n.Calls() != 3 {
+		t.Fatalf("%d calls, want 3", n.Calls())
+	}
+}
+
+func TestPreferLocalRegion(t *testing.T) {
+	remote := newNode(t, 1)
+	local := newNode(t, 2)
+	other := newNode(t, 2)
+	cluster(remote, local, other)
+
+	c, err := New([]string{remote.endpoint()}, WithRegion(2), fastRetry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	eps := c.Endpoints()
+	if len(eps) != 3 || eps[0].URL != local.srv.URL || eps[2].URL != remote.srv.URL || eps[2].Region != 1 {
+		t.Fatalf("endpoints = %+v, want the nodes of region 2 first", eps)
+	}
+
+	ev, err := c.Get(context.Background(), &rpc.GetEventRequest{Id: "k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Data != local.endpoint() {
+		t.Fatalf("answered by %s, want %s", ev.Data, local.endpoint())
+	}
+
+	// a member gone is forgotten, the endpoints given to New are kept
+	local.srv.Close()
+	cluster(remote, other)
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if eps := c.Endpoints(); len(eps) != 2 || eps[0].URL != other.srv.URL {
+		t.Fatalf("endpoints = %+v, want %s then %s", eps, other.srv.URL, remote.srv.URL)
+	}
+}
+
+func TestPin(t *testing.T) {
+	down := newNode(t, 1)
+	down.srv.Close()
+	a, b := newNode(t, 1), newNode(t, 1)
+
+	c, err := New([]string{down.endpoint(), a.endpoint(), b.endpoint()}, fastRetry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := c.Pin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if eps := p.Endpoints(); len(eps) != 1 || eps[0].URL != a.srv.URL {
+		t.Fatalf("pinned to %+v, want %s", eps, a.srv.URL)
+	}
+
+	// the pinned node failing does not send requests elsewhere
+	a.mu.Lock()
+	for i := 0; i < 3; i++ {
+		a.fail = append(a.fail, twirp.NewError(twirp.Unavailable, "busy"))
+	}
+	a.mu.Unlock()
+	if _, err := p.Get(context.Background(), &rpc.GetEventRequest{Id: "k"}); err == nil {
+		t.Fatal("pinned client answered by another node")
+	}
+	if b.Calls() != 0 {
+		t.Fatalf("other node got %d calls, want none", b.Calls())
+	}
+}
+
+func TestNewRejectsInvalidEndpoints(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Fatal("no endpoint accepted")
+	}
+	if _, err := New([]string{"http://"}); err == nil {
+		t.Fatal("endpoint without host accepted")
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/config"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+)
+
+// version is set at build time with -ldflags "-X main.version=..."
+var version string
+
+// shutdownTimeout bounds draining requests, the final sync and leaving the cluster
+const shutdownTimeout = 20 * time.Second
+
+func main() {
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
+	os.Exit(run(os.Args[1:], os.LookupEnv, os.Stderr, shutdown))
+}
+
+// run runs a node until it fails or a signal is received on shutdown, and
+// returns the exit code: 1 if the node failed, 2 if it was misconfigured.
+func run(args []string, lookupEnv func(string) (string, bool), stderr io.Writer, shutdown <-chan os.Signal) int {
+	cfg, err := config.Load("gossip-replicator", args, lookupEnv, stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	level, _ := logging.ParseLevel(cfg.LogLevel)
+	logger := logging.New(stderr, level)
+
+	opts, err := nodeOptions(cfg, logger)
+	if err != nil {
+		logger.Error("failed to open storage", "dir", cfg.DataDir, "error", err)
+		return 1
+	}
+	node := replicator.NewNode(cfg.Name, cfg.RegionID, cfg.BindAddr, cfg.APIPort, cfg.BindPort, "", opts...)
+	errChan := node.Start()
+	logger.Info("node started", "node", cfg.Name, "region", cfg.RegionID)
+
+	var code int
+	select {
+	case <-shutdown:
+		logger.Info("shutting down")
+	case err := <-errChan:
+		logger.Error("node failed, shutting down", "error", err)
+		code = 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	err = node.Shutdown(ctx)
+	cancel()
+	if err != nil {
+		logger.Error("node shutdown failed", "error", err)
+		code = 1
+	}
+	logger.Info("node shut down, exiting")
+	return code
+}
+
+// nodeOptions returns the options of a node configured by cfg, opening its
+// storage if it has a data directory.
+func nodeOptions(cfg config.Config, logger logging.Logger) ([]replicator.Option, error) {
+	opts := []replicator.Option{replicator.WithLogger(logger)}
+	if version != "" {
+		opts = append(opts, replicator.WithBuildVersion(version))
+	}
+	if cfg.DataDir != "" {
+		db, err := storage.NewBadgerDB(storage.Options{
+			Dir:    cfg.DataDir,
+			Logger: logging.With(logger, "node", cfg.Name, "region", cfg.RegionID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, replicator.WithBackend(db))
+	}
+	if len(cfg.Seeds) > 0 {
+		opts = append(opts, replicator.WithSeeds(cfg.Seeds...))
+	}
+	if cfg.SeedDNS != "" {
+		opts = append(opts, replicator.WithSeedDNS(cfg.SeedDNS, cfg.DNSServer))
+	}
+	opts = append(opts, replicator.WithRejoinInterval(cfg.RejoinInterval))
+	opts = append(opts, replicator.WithMaxClockOffset(cfg.MaxClockOffset))
+	for region := uint(1); region <= cfg.NumberOfRegions; region++ {
+		opts = append(opts, replicator.WithRegions(region))
+	}
+	if cfg.AdvertiseAddr != "" || cfg.AdvertisePort != 0 {
+		opts = append(opts, replicator.WithAdvertiseAddr(cfg.AdvertiseAddr, cfg.AdvertisePort))
+	}
+	return opts, nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+// buffer is a bytes.Buffer safe to write while the test reads it.
+type buffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *buffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// freePort returns a port nothing listens on.
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// process is a node run as the command would be.
+type process struct {
+	api    rpc.EventReplicatorService
+	stderr *buffer
+	stop   chan os.Signal
+	code   chan int
+}
+
+// start runs the command with args and env, and waits until it serves its API.
+func start(t *testing.T, env map[string]string, args ...string) *process {
+	t.Helper()
+	apiPort := freePort(t)
+	args = append([]string{"-bind-addr", "127.0.0.1", "-bind-port", fmt.Sprint(freePort(t)),
+		"-api-port", fmt.Sprint(apiPort), "-log-level", "debug"}, args...)
+	lookupEnv := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	p := &process{stderr: &buffer{}, stop: make(chan os.Signal, 1), code: make(chan int, 1)}
+	go func() { p.code <- run(args, lookupEnv, p.stderr, p.stop) }()
+
+	api := rpc.NewEventReplicatorServiceProtobufClient(fmt.Sprintf("http://127.0.0.1:%d", apiPort),
+		http.DefaultClient, twirp.WithClientPathPrefix("/rz"))
+	p.api = api
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, err := api.GetRegions(context.Background(), &rpc.GetRegionsRequest{})
+		if err == nil {
+			return p
+		}
+		select {
+		case code := <-p.code:
+			t.Fatalf("exited with %d before serving:\n%s", code, p.stderr)
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("API not served: %v\n%s", err, p.stderr)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// shutdown signals the process and returns its exit code.
+func (p *process) shutdown(t *testing.T) int {
+	t.Helper()
+	p.stop <- os.Interrupt
+	select {
+	case code := <-p.code:
+		return code
+	case <-time.After(shutdownTimeout + 5*time.Second):
+		t.Fatalf("not shut down:\n%s", p.stderr)
+		return 0
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	// the environment is overridden by the flags given
+	env := map[string]string{
+		"REPLICATOR_NAME":      "node-a",
+		"REPLICATOR_REGION_ID": "1",
+		"REPLICATOR_DATA_DIR":  dir,
+	}
+	p := start(t, env, "-region-id", "2", "-regions", "3")
+	ctx := context.Background()
+
+	regions, err := p.api.GetRegions(ctx, &rpc.GetRegionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int32{1, 2, 3}; !reflect.DeepEqual(regions.Registered, want) {
+		t.Errorf("registered regions %v, want %v", regions.Registered, want)
+	}
+	members, err := p.api.ListMembers(ctx, &rpc.ListMembersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members.Members) != 1 {
+		t.Fatalf("%d members, want the node alone", len(members.Members))
+	}
+	if m := members.Members[0]; m.Name != "node-a" || m.Region != 2 || !m.Local {
+		t.Errorf("member %q in region %d, want node-a in region 2", m.Name, m.Region)
+	}
+	if _, err := p.api.Put(ctx, &rpc.PutEventRequest{Id: "k", Data: "v", SourceRegion: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if code := p.shutdown(t); code != 0 {
+		t.Fatalf("exited with %d, want 0:\n%s", code, p.stderr)
+	}
+
+	// the data directory outlives the process
+	p = start(t, env, "-region-id", "2", "-regions", "3")
+	ev, err := p.api.Get(ctx, &rpc.GetEventRequest{Id: "k"})
+	if err != nil {
+		t.Fatalf("event lost across a restart: %v", err)
+	}
+	if ev.Data != "v" {
+		t.Errorf("event holds %q after a restart, want v", ev.Data)
+	}
+	if code := p.shutdown(t); code != 0 {
+		t.Fatalf("exited with %d, want 0:\n%s", code, p.stderr)
+	}
+}
+
+func TestRunFails(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name   string
+		args   []string
+		env    map[string]string
+		code   int
+		stderr string
+	}{
+		{"help", []string{"-h"}, nil, 0, "-region-id"},
+		{"unknown flag", []string{"-region", "1"}, nil, 2, "flag provided but not defined"},
+		{"no region", []string{"-name", "a"}, nil, 2, "region id"},
+		{"region above regions", []string{"-name", "a", "-region-id", "4", "-regions", "3"}, nil, 2,
+			"region id 4 is above the number of regions 3"},
+		{"bad environment", []string{"-name", "a", "-region-id", "1"}, map[string]string{"REPLICATOR_API_PORT": "x"}, 2,
+			"REPLICATOR_API_PORT"},
+		{"storage", []string{"-name", "a", "-region-id", "1", "-data-dir", file}, nil, 1, "failed to open storage"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr buffer
+			lookupEnv := func(name string) (string, bool) {
+				v, ok := tt.env[name]
+				return v, ok
+			}
+			if code := run(tt.args, lookupEnv, &stderr, nil); code != tt.code {
+				t.Errorf("exited with %d, want %d:\n%s", code, tt.code, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("stderr does not mention %q:\n%s", tt.stderr, stderr.String())
+			}
+		})
+	}
+}
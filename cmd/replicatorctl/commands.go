@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kyawmyintthein/gossip-replicator/client"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+)
+
+const (
+	// snapshotPageSize is the largest page nodes return.
+	snapshotPageSize = 1000
+
+	// listDataWidth truncates data in the table of list.
+	listDataWidth = 40
+)
+
+// parseFlags parses the flags of a command, leaving its arguments in fs.
+func parseFlags(e *env, fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(e.stderr)
+	err := fs.Parse(args)
+	if err != nil && err != flag.ErrHelp {
+		return usagef("%v", err)
+	}
+	return err
+}
+
+func runPut(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("put", flag.ContinueOnError)
+	action := fs.String("action", "", "action name of the event")
+	service := fs.String("service", "", "service code of the event")
+	version := fs.Int("version", 0, "application version of the event")
+	if err := parseFlags(e, fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usagef("want an id and data")
+	}
+	data := fs.Arg(1)
+	if data == "-" {
+		b, err := io.ReadAll(e.stdin)
+		if err != nil {
+			return err
+		}
+		data = string(b)
+	}
+	ev, err := e.client.Put(ctx, &rpc.PutEventRequest{
+		Id:           fs.Arg(0),
+		ActionName:   *action,
+		ServiceCode:  *service,
+		SourceRegion: int32(e.region),
+		Data:         data,
+		Version:      int32(*version),
+	})
+	if err != nil {
+		return err
+	}
+	return e.printEvent(ev)
+}
+
+func runGet(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return usagef("want an id")
+	}
+	ev, err := e.client.Get(ctx, &rpc.GetEventRequest{Id: args[0]})
+	if err != nil {
+		return err
+	}
+	return e.printEvent(ev)
+}
+
+func runDelete(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return usagef("want an id")
+	}
+	ev, err := e.client.Delete(ctx, &rpc.DeleteEventRequest{Id: args[0], SourceRegion: int32(e.region)})
+	if err != nil {
+		return err
+	}
+	if e.output == "json" {
+		return e.printJSON(ev)
+	}
+	fmt.Fprintf(e.stdout, "deleted %s, version %d\n", ev.Id, ev.Meta.GetVersion())
+	return nil
+}
+
+// listFlags are the filters of list and snapshot.
+func listFlags(fs *flag.FlagSet) *rpc.ListEventsRequest {
+	req := &rpc.ListEventsRequest{}
+	fs.StringVar(&req.Prefix, "prefix", "", "only the ids starting with prefix")
+	fs.StringVar(&req.ServiceCode, "service", "", "only the events of a service code")
+	fs.StringVar(&req.ActionName, "action", "", "only the events of an action name")
+	fs.Func("source-region", "only the events written on behalf of a region", func(s string) error {
+		region, err := strconv.ParseInt(s, 10, 32)
+		req.SourceRegion = int32(region)
+		return err
+	})
+	return req
+}
+
+// listAll pages through the events matching req, up to limit if positive.
+func listAll(ctx context.Context, c *client.Client, req *rpc.ListEventsRequest, limit int) ([]*rpc.Event, error) {
+	var events []*rpc.Event
+	for {
+		req.PageSize = snapshotPageSize
+		if limit > 0 && limit-len(events) < snapshotPageSize {
+			req.PageSize = int32(limit - len(events))
+		}
+		resp, err := c.ListEvents(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, resp.Events...)
+		if resp.NextPageToken == "" || (limit > 0 && len(events) >= limit) {
+			return events, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+func runList(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	req := listFlags(fs)
+	limit := fs.Int("limit", 100, "events listed at most, 0 for all")
+	if err := parseFlags(e, fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("unexpected arguments %q", fs.Args())
+	}
+	events, err := listAll(ctx, e.client, req, *limit)
+	if err != nil {
+		return err
+	}
+	if e.output == "json" {
+		return e.printJSON(&rpc.ListEventsResponse{Events: events})
+	}
+
+	t := e.table("ID", "ACTION", "SERVICE", "VERSION", "SOURCE", "CONFLICTS", "DATA")
+	for _, ev := range events {
+		t.row(ev.Id, ev.ActionName, ev.Meta.GetServiceCode(), ev.Meta.GetVersion(), ev.Meta.GetSourceRegion(),
+			len(ev.Siblings), truncate(printable(ev.Data), listDataWidth))
+	}
+	return t.flush()
+}
+
+func runMembers(ctx context.Context, e *env, args []string) error {
+	if len(args) != 0 {
+		return usagef("unexpected arguments %q", args)
+	}
+	resp, err := e.client.ListMembers(ctx, &rpc.ListMembersRequest{})
+	if err != nil {
+		return err
+	}
+	if e.output == "json" {
+		return e.printJSON(resp)
+	}
+
+	t := e.table("NAME", "STATE", "REGION", "ROLE", "ADDRESS", "API", "BUILD", "PROTOCOL")
+	for _, m := range resp.Members {
+		name := m.Name
+		if m.Local {
+			name += " *"
+		}
+		t.row(name, m.State, m.Region, m.Role, m.Address, m.ApiEndpoint, m.BuildVersion, m.ProtocolVersion)
+	}
+	return t.flush()
+}
+
+func runStatus(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	stuckAfter := fs.Duration("stuck-after", 5*time.Minute, "how long an event is pending before it is stuck")
+	maxStuck := fs.Int("max-stuck", 20, "stuck events listed at most")
+	if err := parseFlags(e, fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("unexpected arguments %q", fs.Args())
+	}
+	if *stuckAfter < time.Second {
+		return usagef("-stuck-after must be at least 1s")
+	}
+	status, err := e.client.GetReplicationStatus(ctx, &rpc.GetReplicationStatusRequest{
+		StuckAfterSeconds: int64(*stuckAfter / time.Second),
+		MaxStuckKeys:      int32(*maxStuck),
+	})
+	if err != nil {
+		return err
+	}
+	if e.output == "json" {
+		return e.printJSON(status)
+	}
+
+	fmt.Fprintf(e.stdout, "region %d, %d regions\n", status.RegionId, status.NumberOfRegions)
+	fmt.Fprintf(e.stdout, "%d events, %d pending, oldest pending for %s, %d stuck\n\n",
+		status.TotalEvents, status.PendingEvents, seconds(status.OldestPendingAgeSeconds), status.StuckEvents)
+	t := e.table("REGION", "PENDING", "OLDEST PENDING")
+	for _, r := range status.Regions {
+		t.row(r.Region, r.PendingEvents, seconds(r.OldestPendingAgeSeconds))
+	}
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if len(status.Stuck) == 0 {
+		return nil
+	}
+	fmt.Fprintln(e.stdout)
+	t = e.table("STUCK", "AGE", "MISSING REGIONS")
+	for _, s := range status.Stuck {
+		missing := make([]string, len(s.MissingRegions))
+		for i, r := range s.MissingRegions {
+			missing[i] = strconv.Itoa(int(r))
+		}
+		t.row(s.Id, seconds(s.AgeSeconds), strings.Join(missing, ","))
+	}
+	return t.flush()
+}
+
+func runQuarantine(ctx context.Context, e *env, args []string) error {
+	if len(args) != 0 {
+		return usagef("unexpected arguments %q", args)
+	}
+	resp, err := e.client.ListQuarantined(ctx, &rpc.ListQuarantinedRequest{})
+	if err != nil {
+		return err
+	}
+	if e.output == "json" {
+		return e.printJSON(resp)
+	}
+
+	fmt.Fprintf(e.stdout, "%d bad payloads received, the last %d kept\n\n", resp.Total, len(resp.Payloads))
+	t := e.table("RECEIVED", "KIND", "KEY", "SIZE", "ERROR")
+	for _, p := range resp.Payloads {
+		t.row(time.Unix(0, p.ReceivedAt).UTC().Format(time.RFC3339), p.Kind, p.Key, len(p.Payload), p.Error)
+	}
+	return t.flush()
+}
+
+func runSnapshot(ctx context.Context, e *env, args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	req := listFlags(fs)
+	file := fs.String("file", "", "file to write to instead of stdout")
+	if err := parseFlags(e, fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("unexpected arguments %q", fs.Args())
+	}
+	// pages of different nodes would not add up to the events of one
+	pinned, err := e.client.Pin(ctx)
+	if err != nil {
+		return err
+	}
+	defer pinned.Close()
+	events, err := listAll(ctx, pinned, req, 0)
+	if err != nil {
+		return err
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Id < events[j].Id })
+	b, err := marshalJSON(&rpc.ListEventsResponse{Events: events})
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		_, err = e.stdout.Write(b)
+		return err
+	}
+	err = writeFile(*file, b)
+	if err != nil {
+		return err
+	}
+	if e.output == "table" {
+		fmt.Fprintf(e.stdout, "wrote %d events to %s\n", len(events), *file)
+	}
+	return nil
+}
+
+// writeFile writes b to a temporary file renamed to name once complete.
+func writeFile(name string, b []byte) error {
+	tmp := name + ".tmp"
+	err := os.WriteFile(tmp, b, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+// printEvent prints an event and its siblings, one version per row.
+func (e *env) printEvent(ev *rpc.Event) error {
+	if e.output == "json" {
+		return e.printJSON(ev)
+	}
+	t := e.table("ID", "VERSION", "SOURCE", "WRITTEN", "VECTOR", "COMMITTED", "DATA")
+	for _, v := range append([]*rpc.Event{ev}, ev.Siblings...) {
+		data := printable(v.Data)
+		if v.Meta.GetTombstone() {
+			data = "(deleted)"
+		}
+		t.row(ev.Id, v.Meta.GetVersion(), v.Meta.GetSourceRegion(), written(v.Meta),
+			vector(v.Meta.GetVector()), committed(v.Meta), data)
+	}
+	return t.flush()
+}
+
+func written(m *rpc.Meta) string {
+	wall := m.GetTimestamp().GetWallTime()
+	if wall == 0 {
+		return "-"
+	}
+	return time.Unix(0, wall).UTC().Format(time.RFC3339)
+}
+
+func vector(entries []*rpc.VectorEntry) string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = fmt.Sprintf("%d:%d", e.Region, e.Counter)
+	}
+	return strings.Join(out, " ")
+}
+
+// committed lists the regions that committed a version, sorted.
+func committed(m *rpc.Meta) string {
+	var regions []int
+	for _, p := range m.GetCommitedRegions().GetPairs() {
+		if p.Value {
+			regions = append(regions, int(p.Key))
+		}
+	}
+	sort.Ints(regions)
+	out := make([]string, len(regions))
+	for i, r := range regions {
+		out[i] = strconv.Itoa(r)
+	}
+	return strings.Join(out, ",")
+}
+
+func seconds(s int64) string {
+	return (time.Duration(s) * time.Second).String()
+}
+
+// printable quotes data that would break a table row.
+func printable(data string) string {
+	if strings.IndexFunc(data, unicode.IsControl) >= 0 {
+		return strconv.Quote(data)
+	}
+	return data
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-3]) + "..."
+}
@@ -0,0 +1,166 @@
+// Command replicatorctl operates a replicator cluster through the API of
+// its nodes.
+//
+//	replicatorctl [flags] <command> [command flags] [arguments]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/client"
+	"github.com/twitchtv/twirp"
+)
+
+// version is set at build time with -ldflags "-X main.version=..."
+var version string
+
+const (
+	defaultEndpoint = "localhost:9000"
+	defaultTimeout  = 10 * time.Second
+
+	envEndpoint = "REPLICATOR_ENDPOINT"
+)
+
+// command is a subcommand of replicatorctl.
+type command struct {
+	name  string
+	args  string
+	usage string
+	run   func(ctx context.Context, env *env, args []string) error
+}
+
+var commands = []command{
+	{"put", "[flags] <id> <data|->", "write an event, reading data from stdin if -", runPut},
+	{"get", "<id>", "read an event and its conflicting versions", runGet},
+	{"delete", "<id>", "delete an event", runDelete},
+	{"list", "[flags]", "list the events of a node", runList},
+	{"members", "", "list the members of the cluster seen by a node", runMembers},
+	{"status", "[flags]", "show the replication status of a node", runStatus},
+	{"snapshot", "[flags]", "dump every event of a node as JSON", runSnapshot},
+	{"quarantine", "", "list the bad gossip payloads a node received", runQuarantine},
+}
+
+// env is what commands run with.
+type env struct {
+	client *client.Client
+	region uint
+	output string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs replicatorctl and returns its exit code: 1 if the command
+// failed, 2 if it was misused.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("replicatorctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	endpoint := defaultEndpoint
+	if v, ok := os.LookupEnv(envEndpoint); ok {
+		endpoint = v
+	}
+	fs.StringVar(&endpoint, "endpoint", endpoint, "comma separated host:port or URLs of node APIs, or $"+envEndpoint)
+	region := fs.Uint("region", 0, "region writes are made on behalf of, whose nodes are preferred")
+	output := fs.String("output", "table", "output format, table or json")
+	timeout := fs.Duration("timeout", defaultTimeout, "time limit of the command")
+	printVersion := fs.Bool("version", false, "print the version and exit")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: replicatorctl [flags] <command> [command flags] [arguments]\n\nCommands:\n")
+		for _, c := range commands {
+			fmt.Fprintf(stderr, "  %-10s %s\n", c.name, c.usage)
+		}
+		fmt.Fprintf(stderr, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *printVersion {
+		fmt.Fprintln(stdout, version)
+		return 0
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "replicatorctl: unknown output %q, want table or json\n", *output)
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	cmd, ok := lookup(fs.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "replicatorctl: unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	opts := []client.Option{client.WithRegion(*region)}
+	c, err := client.New(strings.Split(endpoint, ","), opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "replicatorctl: %v\n", err)
+		return 2
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	e := &env{client: c, region: *region, output: *output, stdin: stdin, stdout: stdout, stderr: stderr}
+	err = cmd.run(ctx, e, fs.Args()[1:])
+	var usage usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &usage):
+		fmt.Fprintf(stderr, "replicatorctl %s: %v\nUsage: replicatorctl %s %s\n", cmd.name, err, cmd.name, cmd.args)
+		return 2
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	default:
+		fmt.Fprintf(stderr, "replicatorctl %s: %s\n", cmd.name, describeError(err))
+		return 1
+	}
+}
+
+func lookup(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// usageError is a command misused.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...interface{}) error {
+	return usageError{fmt.Sprintf(format, args...)}
+}
+
+// describeError spells out the code of twirp errors, e.g. "not found: event
+// not found", and returns other errors as is.
+func describeError(err error) string {
+	var twerr twirp.Error
+	if errors.As(err, &twerr) && twerr.Code() != twirp.Internal {
+		return strings.ReplaceAll(string(twerr.Code()), "_", " ") + ": " + twerr.Msg()
+	}
+	return err.Error()
+}
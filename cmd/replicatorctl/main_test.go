@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// node serves the API of a node holding events, listed two per page.
+type node struct {
+	rpc.EventReplicatorService
+
+	mu     sync.Mutex
+	events map[string]*rpc.Event
+	pages  int // ListEvents calls
+
+	srv *httptest.Server
+}
+
+func newNode(t *testing.T, ids ...string) *node {
+	n := &node{events: make(map[string]*rpc.Event)}
+	for _, id := range ids {
+		n.events[id] = &rpc.Event{Id: id, Data: "data of " + id, Meta: &rpc.Meta{Version: 1, SourceRegion: 1}}
+	}
+	n.srv = httptest.NewServer(rpc.NewEventReplicatorServiceServer(n, twirp.WithServerPathPrefix("/rz")))
+	t.Cleanup(n.srv.Close)
+	return n
+}
+
+func (n *node) endpoint() string {
+	return strings.TrimPrefix(n.srv.URL, "http://")
+}
+
+func (n *node) Put(ctx context.Context, req *rpc.PutEventRequest) (*rpc.Event, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ev := &rpc.Event{Id: req.Id, ActionName: req.ActionName, Data: req.Data,
+		Meta: &rpc.Meta{Version: 1, SourceRegion: req.SourceRegion, ServiceCode: req.ServiceCode}}
+	n.events[req.Id] = ev
+	return ev, nil
+}
+
+func (n *node) Get(ctx context.Context, req *rpc.GetEventRequest) (*rpc.Event, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ev, ok := n.events[req.Id]
+	if !ok {
+		return nil, twirp.NotFoundError("event not found")
+	}
+	return ev, nil
+}
+
+func (n *node) ListEvents(ctx context.Context, req *rpc.ListEventsRequest) (*rpc.ListEventsResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.pages++
+	var ids []string
+	for id := range n.events {
+		if strings.HasPrefix(id, req.Prefix) && id > req.PageToken {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	resp := &rpc.ListEventsResponse{}
+	for _, id := range ids {
+		if len(resp.Events) == 2 || len(resp.Events) == int(req.PageSize) {
+			resp.NextPageToken = resp.Events[len(resp.Events)-1].Id
+			break
+		}
+		resp.Events = append(resp.Events, n.events[id])
+	}
+	return resp, nil
+}
+
+func (n *node) GetRegions(ctx context.Context, req *rpc.GetRegionsRequest) (*rpc.RegionRegistry, error) {
+	return &rpc.RegionRegistry{}, nil
+}
+
+func (n *node) ListQuarantined(ctx context.Context, req *rpc.ListQuarantinedRequest) (*rpc.ListQuarantinedResponse, error) {
+	return &rpc.ListQuarantinedResponse{Total: 3, Payloads: []*rpc.BadPayload{
+		{Kind: "entry", Key: "k", Payload: []byte("\x00\x01"), Error: "unexpected end of JSON input"},
+	}}, nil
+}
+
+func (n *node) ListCalls() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.pages
+}
+
+// replicatorctl runs the command line with stdin and returns its exit code and output.
+func replicatorctl(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRunUsage(t *testing.T) {
+	n := newNode(t)
+	for _, tt := range []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"no command", []string{}, 2, "Usage: replicatorctl"},
+		{"unknown command", []string{"frobnicate"}, 2, `unknown command "frobnicate"`},
+		{"unknown output", []string{"-output", "xml", "get", "k"}, 2, `unknown output "xml"`},
+		{"missing arguments", []string{"-endpoint", n.endpoint(), "get"}, 2, "Usage: replicatorctl get <id>"},
+		{"unknown command flag", []string{"-endpoint", n.endpoint(), "list", "-nope"}, 2, "flag provided but not defined"},
+		{"help", []string{"-h"}, 0, "Commands:"},
+		{"version", []string{"-version"}, 0, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := replicatorctl("", tt.args...)
+			if code != tt.code || !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("exited %d with %q, want %d with %q", code, stderr, tt.code, tt.stderr)
+			}
+		})
+	}
+}
+
+func TestRunCommands(t *testing.T) {
+	n := newNode(t, "a", "b", "c")
+	ep := n.endpoint()
+
+	code, stdout, stderr := replicatorctl("from stdin", "-endpoint", ep, "-region", "2", "put", "-service", "billing", "k", "-")
+	if code != 0 {
+		t.Fatalf("put exited %d: %s", code, stderr)
+	}
+	if ev, _ := n.Get(context.Background(), &rpc.GetEventRequest{Id: "k"}); ev.Data != "from stdin" || ev.Meta.SourceRegion != 2 {
+		t.Errorf("put stored %v, want the data read from stdin on behalf of region 2", ev)
+	}
+	if !strings.Contains(stdout, "from stdin") {
+		t.Errorf("put printed %q", stdout)
+	}
+
+	code, stdout, _ = replicatorctl("", "-endpoint", ep, "-output", "json", "get", "a")
+	var ev rpc.Event
+	if code != 0 || protojson.Unmarshal([]byte(stdout), &ev) != nil || ev.Id != "a" {
+		t.Errorf("get exited %d printing %q, want the JSON of event a", code, stdout)
+	}
+
+	code, _, stderr = replicatorctl("", "-endpoint", ep, "get", "missing")
+	if code != 1 || !strings.Contains(stderr, "not found: event not found") {
+		t.Errorf("get of a missing event exited %d with %q", code, stderr)
+	}
+
+	code, stdout, _ = replicatorctl("", "-endpoint", ep, "list", "-limit", "3")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 4 || !strings.HasPrefix(lines[0], "ID") {
+		t.Errorf("list exited %d printing %q, want a header and 3 events", code, stdout)
+	}
+
+	code, stdout, _ = replicatorctl("", "-endpoint", ep, "quarantine")
+	if code != 0 || !strings.Contains(stdout, "3 bad payloads received, the last 1 kept") ||
+		!strings.Contains(stdout, "unexpected end of JSON input") {
+		t.Errorf("quarantine exited %d printing %q", code, stdout)
+	}
+}
+
+func TestRunSnapshot(t *testing.T) {
+	// the nodes disagree: pages of both would mix their events
+	a := newNode(t, "a1", "a2", "a3", "a4", "a5")
+	b := newNode(t, "b1", "b2", "b3")
+	file := filepath.Join(t.TempDir(), "snapshot.json")
+
+	code, stdout, stderr := replicatorctl("", "-endpoint", a.endpoint()+","+b.endpoint(), "snapshot", "-file", file)
+	if code != 0 {
+		t.Fatalf("snapshot exited %d: %s", code, stderr)
+	}
+	if stdout != "wrote 5 events to "+file+"\n" {
+		t.Errorf("snapshot printed %q", stdout)
+	}
+	if b.ListCalls() != 0 {
+		t.Errorf("%d pages read from another node", b.ListCalls())
+	}
+
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &rpc.ListEventsResponse{}
+	for _, id := range []string{"a1", "a2", "a3", "a4", "a5"} {
+		want.Events = append(want.Events, a.events[id])
+	}
+	wantJSON, err := marshalJSON(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, wantJSON) {
+		t.Errorf("wrote\n%s\nwant\n%s", got, wantJSON)
+	}
+	if _, err := os.Stat(file + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	// the same JSON on stdout
+	code, stdout, _ = replicatorctl("", "-endpoint", a.endpoint(), "snapshot")
+	var resp rpc.ListEventsResponse
+	if code != 0 || protojson.Unmarshal([]byte(stdout), &resp) != nil || len(resp.Events) != 5 {
+		t.Fatalf("snapshot exited %d printing %q", code, stdout)
+	}
+	if !proto.Equal(resp.Events[0], a.events["a1"]) {
+		t.Errorf("first event %v, want %v", resp.Events[0], a.events["a1"])
+	}
+}
@@ -0,0 +1,354 @@
+// Package config loads the settings of a single replicator node from a
+// YAML or TOML file, environment variables and command line flags, in
+// increasing order of precedence.
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/hlc"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes every environment variable read by Load.
+const EnvPrefix = "REPLICATOR_"
+
+// Config describes one node of the cluster.
+type Config struct {
+	// Name identifies the node in the cluster; it must be unique.
+	Name string `yaml:"name" toml:"name"`
+
+	// RegionID is the region this node replicates for.
+	RegionID uint `yaml:"region_id" toml:"region_id"`
+
+	// NumberOfRegions is how many regions must commit an event.
+	NumberOfRegions uint `yaml:"number_of_regions" toml:"number_of_regions"`
+
+	// BindAddr and BindPort is where the node gossips with its peers.
+	BindAddr string `yaml:"bind_addr" toml:"bind_addr"`
+	BindPort int    `yaml:"bind_port" toml:"bind_port"`
+
+	// AdvertiseAddr and AdvertisePort is the gossip address given to peers,
+	// when it differs from the bind address (e.g. behind NAT).
+	AdvertiseAddr string `yaml:"advertise_addr" toml:"advertise_addr"`
+	AdvertisePort int    `yaml:"advertise_port" toml:"advertise_port"`
+
+	// APIPort serves the EventReplicatorService.
+	APIPort int `yaml:"api_port" toml:"api_port"`
+
+	// Seeds lists host:port gossip addresses of nodes to join; empty for the first node.
+	Seeds []string `yaml:"seeds" toml:"seeds"`
+
+	// SeedDNS discovers seeds in DNS: an SRV record when it starts with an
+	// underscore (e.g. _gossip._tcp.replicator.local), A/AAAA records otherwise.
+	SeedDNS string `yaml:"seed_dns" toml:"seed_dns"`
+
+	// DNSServer is the host:port of the DNS server to query for SeedDNS;
+	// the system resolver is used when empty.
+	DNSServer string `yaml:"dns_server" toml:"dns_server"`
+
+	// RejoinInterval is how often a node left alone tries its seeds again.
+	RejoinInterval time.Duration `yaml:"rejoin_interval" toml:"rejoin_interval"`
+
+	// MaxClockOffset is how far ahead of the local clock the timestamps of
+	// events from peers may be; events further ahead are merged once the
+	// local clock catches up. 0 accepts any timestamp.
+	MaxClockOffset time.Duration `yaml:"max_clock_offset" toml:"max_clock_offset"`
+
+	// DataDir persists the node state; it is kept in memory when empty.
+	DataDir string `yaml:"data_dir" toml:"data_dir"`
+
+	// LogLevel is the minimum level logged: debug, info, warn or error.
+	LogLevel string `yaml:"log_level" toml:"log_level"`
+}
+
+// Default returns the configuration used for anything left unset.
+func Default() Config {
+	return Config{
+		NumberOfRegions: 1,
+		BindAddr:        "0.0.0.0",
+		BindPort:        7900,
+		APIPort:         9000,
+		RejoinInterval:  30 * time.Second,
+		MaxClockOffset:  hlc.DefaultMaxOffset,
+		LogLevel:        "info",
+	}
+}
+
+// Load builds the configuration from the defaults, the file given with
+// -config (or REPLICATOR_CONFIG), the REPLICATOR_* environment variables
+// and the command line flags in args, each overriding the previous ones.
+// The result is validated.
+func Load(name string, args []string, lookupEnv func(string) (string, bool), output io.Writer) (Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	var (
+		file  = fs.String("config", "", "path to a YAML (.yaml, .yml) or TOML (.toml) config file")
+		flags = cfg
+		seeds string
+	)
+	fs.StringVar(&flags.Name, "name", "", "unique name of the node in the cluster")
+	fs.UintVar(&flags.RegionID, "region-id", 0, "region this node replicates for")
+	fs.UintVar(&flags.NumberOfRegions, "regions", cfg.NumberOfRegions, "number of regions that must commit an event")
+	fs.StringVar(&flags.BindAddr, "bind-addr", cfg.BindAddr, "address to gossip on")
+	fs.IntVar(&flags.BindPort, "bind-port", cfg.BindPort, "port to gossip on")
+	fs.StringVar(&flags.AdvertiseAddr, "advertise-addr", "", "gossip address advertised to peers, defaults to the bind address")
+	fs.IntVar(&flags.AdvertisePort, "advertise-port", 0, "gossip port advertised to peers, defaults to the bind port")
+	fs.IntVar(&flags.APIPort, "api-port", cfg.APIPort, "port serving the replicator API")
+	fs.StringVar(&seeds, "seeds", "", "comma separated host:port gossip addresses of nodes to join")
+	fs.StringVar(&flags.SeedDNS, "seed-dns", "", "DNS name to discover seeds from, an SRV record if it starts with an underscore")
+	fs.StringVar(&flags.DNSServer, "dns-server", "", "host:port of the DNS server resolving -seed-dns, the system resolver if empty")
+	fs.DurationVar(&flags.RejoinInterval, "rejoin-interval", cfg.RejoinInterval, "how often a node left alone tries its seeds again")
+	fs.DurationVar(&flags.MaxClockOffset, "max-clock-offset", cfg.MaxClockOffset, "how far ahead of the local clock events from peers may be stamped, 0 for no limit")
+	fs.StringVar(&flags.DataDir, "data-dir", "", "directory to persist the node state in, kept in memory if empty")
+	fs.StringVar(&flags.LogLevel, "log-level", cfg.LogLevel, "minimum level logged: debug, info, warn or error")
+	err := fs.Parse(args)
+	if err != nil {
+		return Config{}, err
+	}
+	if fs.NArg() > 0 {
+		return Config{}, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	if *file == "" {
+		*file, _ = lookupEnv(EnvPrefix + "CONFIG")
+	}
+	if *file != "" {
+		err = cfg.loadFile(*file)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	err = cfg.loadEnv(lookupEnv)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// only flags given explicitly override the file and the environment
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			cfg.Name = flags.Name
+		case "region-id":
+			cfg.RegionID = flags.RegionID
+		case "regions":
+			cfg.NumberOfRegions = flags.NumberOfRegions
+		case "bind-addr":
+			cfg.BindAddr = flags.BindAddr
+		case "bind-port":
+			cfg.BindPort = flags.BindPort
+		case "advertise-addr":
+			cfg.AdvertiseAddr = flags.AdvertiseAddr
+		case "advertise-port":
+			cfg.AdvertisePort = flags.AdvertisePort
+		case "api-port":
+			cfg.APIPort = flags.APIPort
+		case "seeds":
+			cfg.Seeds = splitList(seeds)
+		case "seed-dns":
+			cfg.SeedDNS = flags.SeedDNS
+		case "dns-server":
+			cfg.DNSServer = flags.DNSServer
+		case "rejoin-interval":
+			cfg.RejoinInterval = flags.RejoinInterval
+		case "max-clock-offset":
+			cfg.MaxClockOffset = flags.MaxClockOffset
+		case "data-dir":
+			cfg.DataDir = flags.DataDir
+		case "log-level":
+			cfg.LogLevel = flags.LogLevel
+		}
+	})
+
+	err = cfg.Validate()
+	if err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// loadFile decodes path on top of cfg, picking the format from the extension.
+func (cfg *Config) loadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, cfg)
+	case ".toml":
+		err = toml.Unmarshal(b, cfg)
+	default:
+		return fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv applies the REPLICATOR_* environment variables on top of cfg.
+func (cfg *Config) loadEnv(lookupEnv func(string) (string, bool)) error {
+	str := func(name string, dst *string) {
+		if v, ok := lookupEnv(EnvPrefix + name); ok {
+			*dst = v
+		}
+	}
+	num := func(name string, dst *int) error {
+		v, ok := lookupEnv(EnvPrefix + name)
+		if !ok {
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("environment variable %s%s: %q is not a number", EnvPrefix, name, v)
+		}
+		*dst = n
+		return nil
+	}
+	unum := func(name string, dst *uint) error {
+		v, ok := lookupEnv(EnvPrefix + name)
+		if !ok {
+			return nil
+		}
+		n, err := strconv.ParseUint(v, 10, 0)
+		if err != nil {
+			return fmt.Errorf("environment variable %s%s: %q is not a positive number", EnvPrefix, name, v)
+		}
+		*dst = uint(n)
+		return nil
+	}
+	duration := func(name string, dst *time.Duration) error {
+		v, ok := lookupEnv(EnvPrefix + name)
+		if !ok {
+			return nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("environment variable %s%s: %q is not a duration", EnvPrefix, name, v)
+		}
+		*dst = d
+		return nil
+	}
+
+	str("NAME", &cfg.Name)
+	str("BIND_ADDR", &cfg.BindAddr)
+	str("ADVERTISE_ADDR", &cfg.AdvertiseAddr)
+	str("DATA_DIR", &cfg.DataDir)
+	str("SEED_DNS", &cfg.SeedDNS)
+	str("DNS_SERVER", &cfg.DNSServer)
+	str("LOG_LEVEL", &cfg.LogLevel)
+	if v, ok := lookupEnv(EnvPrefix + "SEEDS"); ok {
+		cfg.Seeds = splitList(v)
+	}
+	for _, err := range []error{
+		unum("REGION_ID", &cfg.RegionID),
+		unum("NUMBER_OF_REGIONS", &cfg.NumberOfRegions),
+		num("BIND_PORT", &cfg.BindPort),
+		num("ADVERTISE_PORT", &cfg.AdvertisePort),
+		num("API_PORT", &cfg.APIPort),
+		duration("REJOIN_INTERVAL", &cfg.RejoinInterval),
+		duration("MAX_CLOCK_OFFSET", &cfg.MaxClockOffset),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidationError lists every problem found in a configuration.
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// Validate checks that cfg can start a node.
+func (cfg Config) Validate() error {
+	var errs ValidationError
+	if cfg.Name == "" {
+		errs = append(errs, "name is required (-name, REPLICATOR_NAME or name)")
+	}
+	if cfg.RegionID == 0 {
+		errs = append(errs, "region id is required and must be at least 1 (-region-id, REPLICATOR_REGION_ID or region_id)")
+	}
+	if cfg.NumberOfRegions == 0 {
+		errs = append(errs, "number of regions must be at least 1")
+	} else if cfg.RegionID > cfg.NumberOfRegions {
+		errs = append(errs, fmt.Sprintf("region id %d is above the number of regions %d", cfg.RegionID, cfg.NumberOfRegions))
+	}
+	if net.ParseIP(cfg.BindAddr) == nil {
+		errs = append(errs, fmt.Sprintf("bind address %q is not an IP address", cfg.BindAddr))
+	}
+	if cfg.AdvertiseAddr != "" && net.ParseIP(cfg.AdvertiseAddr) == nil {
+		errs = append(errs, fmt.Sprintf("advertise address %q is not an IP address", cfg.AdvertiseAddr))
+	}
+	if !validPort(cfg.BindPort) {
+		errs = append(errs, fmt.Sprintf("bind port %d is out of range 1-65535", cfg.BindPort))
+	}
+	if cfg.AdvertisePort != 0 && !validPort(cfg.AdvertisePort) {
+		errs = append(errs, fmt.Sprintf("advertise port %d is out of range 1-65535", cfg.AdvertisePort))
+	}
+	if !validPort(cfg.APIPort) {
+		errs = append(errs, fmt.Sprintf("api port %d is out of range 1-65535", cfg.APIPort))
+	}
+	if cfg.APIPort == cfg.BindPort {
+		errs = append(errs, fmt.Sprintf("api port and bind port must differ, both are %d", cfg.APIPort))
+	}
+	for _, seed := range cfg.Seeds {
+		_, port, err := net.SplitHostPort(seed)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("seed %q is not a host:port address", seed))
+			continue
+		}
+		if p, err := strconv.Atoi(port); err != nil || !validPort(p) {
+			errs = append(errs, fmt.Sprintf("seed %q has an invalid port", seed))
+		}
+	}
+	if cfg.MaxClockOffset < 0 {
+		errs = append(errs, fmt.Sprintf("max clock offset %s must not be negative", cfg.MaxClockOffset))
+	}
+	if _, err := logging.ParseLevel(cfg.LogLevel); err != nil {
+		errs = append(errs, fmt.Sprintf("log level %q must be debug, info, warn or error", cfg.LogLevel))
+	}
+	if cfg.DNSServer != "" {
+		if _, _, err := net.SplitHostPort(cfg.DNSServer); err != nil {
+			errs = append(errs, fmt.Sprintf("dns server %q is not a host:port address", cfg.DNSServer))
+		}
+		if cfg.SeedDNS == "" {
+			errs = append(errs, "dns server is set but there is no seed dns name to resolve")
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
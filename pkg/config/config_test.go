@@ -0,0 +1,198 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// env returns a lookupEnv reading vars.
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestLoadSamples(t *testing.T) {
+	for _, tt := range []struct {
+		file string
+		want Config
+	}{
+		{"../../config/node1.yaml", Config{
+			Name:            "node1",
+			RegionID:        1,
+			NumberOfRegions: 3,
+			BindAddr:        "127.0.0.1",
+			BindPort:        7900,
+			APIPort:         9000,
+			RejoinInterval:  30 * time.Second,
+			MaxClockOffset:  500 * time.Millisecond,
+			LogLevel:        "info",
+		}},
+		{"../../config/node3.toml", Config{
+			Name:            "node3",
+			RegionID:        3,
+			NumberOfRegions: 3,
+			BindAddr:        "127.0.0.1",
+			BindPort:        7902,
+			APIPort:         9002,
+			Seeds:           []string{"127.0.0.1:7900", "127.0.0.1:7901"},
+			RejoinInterval:  30 * time.Second,
+			MaxClockOffset:  500 * time.Millisecond,
+			LogLevel:        "info",
+		}},
+	} {
+		t.Run(filepath.Base(tt.file), func(t *testing.T) {
+			cfg, err := Load("test", []string{"-config", tt.file}, env(nil), io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cfg, tt.want) {
+				t.Errorf("loaded %+v, want %+v", cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	vars := map[string]string{
+		"REPLICATOR_CONFIG":           "../../config/node1.yaml",
+		"REPLICATOR_NAME":             "from-env",
+		"REPLICATOR_API_PORT":         "9100",
+		"REPLICATOR_SEEDS":            "10.0.0.1:7900, 10.0.0.2:7900",
+		"REPLICATOR_REJOIN_INTERVAL":  "5s",
+		"REPLICATOR_MAX_CLOCK_OFFSET": "1s",
+	}
+	args := []string{"-name", "from-flags", "-log-level", "debug", "-seeds", "10.0.0.3:7900", "-max-clock-offset", "2s"}
+	cfg, err := Load("test", args, env(vars), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Config{
+		Name:            "from-flags", // flag over env over file
+		RegionID:        1,            // file
+		NumberOfRegions: 3,            // file over default
+		BindAddr:        "127.0.0.1",  // file, the flag default does not override it
+		BindPort:        7900,
+		APIPort:         9100, // env over file
+		Seeds:           []string{"10.0.0.3:7900"},
+		RejoinInterval:  5 * time.Second,
+		MaxClockOffset:  2 * time.Second, // flag over env
+		LogLevel:        "debug",
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("loaded %+v, want %+v", cfg, want)
+	}
+
+	// -config wins over REPLICATOR_CONFIG
+	cfg, err = Load("test", []string{"-config", "../../config/node3.toml"}, env(vars), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RegionID != 3 || cfg.Name != "from-env" {
+		t.Errorf("loaded region %d and name %q, want region 3 from the file and the name from env", cfg.RegionID, cfg.Name)
+	}
+
+	// defaults without a file
+	cfg, err = Load("test", []string{"-name", "n", "-region-id", "1"}, env(nil), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = Default()
+	want.Name, want.RegionID = "n", 1
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("loaded %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte("name: [unclosed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ini := filepath.Join(dir, "node.ini")
+	if err := os.WriteFile(ini, []byte("name=n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	required := []string{"-name", "n", "-region-id", "1"}
+	for _, tt := range []struct {
+		name string
+		args []string
+		vars map[string]string
+		want string
+	}{
+		{"missing file", []string{"-config", filepath.Join(dir, "none.yaml")}, nil, "failed to read config file"},
+		{"malformed file", []string{"-config", bad}, nil, "bad.yaml"},
+		{"unsupported format", []string{"-config", ini}, nil, "unsupported format"},
+		{"unexpected arguments", append(required, "extra"), nil, "unexpected arguments: extra"},
+		{"unknown flag", []string{"-nope"}, nil, "flag provided but not defined"},
+		{"port not a number", required, map[string]string{"REPLICATOR_API_PORT": "http"}, `REPLICATOR_API_PORT: "http" is not a number`},
+		{"negative region", required, map[string]string{"REPLICATOR_REGION_ID": "-1"}, `REPLICATOR_REGION_ID: "-1" is not a positive number`},
+		{"bad duration", required, map[string]string{"REPLICATOR_REJOIN_INTERVAL": "often"}, "is not a duration"},
+		{"bad clock offset", required, map[string]string{"REPLICATOR_MAX_CLOCK_OFFSET": "1"}, `REPLICATOR_MAX_CLOCK_OFFSET: "1" is not a duration`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load("test", tt.args, env(tt.vars), io.Discard)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Default()
+	valid.Name, valid.RegionID, valid.NumberOfRegions = "n", 2, 3
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("default config with a name and region: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		modify func(c *Config)
+		want   []string
+	}{
+		{"name", func(c *Config) { c.Name = "" }, []string{"name is required"}},
+		{"region", func(c *Config) { c.RegionID = 0 }, []string{"region id is required"}},
+		{"regions", func(c *Config) { c.NumberOfRegions = 0 }, []string{"number of regions must be at least 1"}},
+		{"region above regions", func(c *Config) { c.RegionID = 4 }, []string{"region id 4 is above the number of regions 3"}},
+		{"bind address", func(c *Config) { c.BindAddr = "localhost" }, []string{`bind address "localhost" is not an IP address`}},
+		{"advertise address", func(c *Config) { c.AdvertiseAddr = "example.com" }, []string{`advertise address "example.com" is not an IP address`}},
+		{"bind port", func(c *Config) { c.BindPort = 70000 }, []string{"bind port 70000 is out of range"}},
+		{"advertise port", func(c *Config) { c.AdvertisePort = -1 }, []string{"advertise port -1 is out of range"}},
+		{"api port", func(c *Config) { c.APIPort = 0 }, []string{"api port 0 is out of range"}},
+		{"same ports", func(c *Config) { c.APIPort = c.BindPort }, []string{"api port and bind port must differ"}},
+		{"seed", func(c *Config) { c.Seeds = []string{"10.0.0.1"} }, []string{`seed "10.0.0.1" is not a host:port address`}},
+		{"seed port", func(c *Config) { c.Seeds = []string{"10.0.0.1:0"} }, []string{`seed "10.0.0.1:0" has an invalid port`}},
+		{"max clock offset", func(c *Config) { c.MaxClockOffset = -time.Second }, []string{"max clock offset -1s must not be negative"}},
+		{"log level", func(c *Config) { c.LogLevel = "loud" }, []string{`log level "loud"`}},
+		{"dns server", func(c *Config) { c.SeedDNS, c.DNSServer = "replicator.local", "10.0.0.53" }, []string{`dns server "10.0.0.53" is not a host:port address`}},
+		{"dns server without name", func(c *Config) { c.DNSServer = "10.0.0.53:53" }, []string{"no seed dns name"}},
+		{"every problem", func(c *Config) { c.Name, c.RegionID, c.LogLevel = "", 0, "loud" }, []string{"name is required", "region id is required", "log level"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			err := c.Validate()
+			var verr ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want a ValidationError", err)
+			}
+			if len(verr) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %d problems", err, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(verr[i], want) {
+					t.Errorf("problem %d is %q, want it to contain %q", i, verr[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Package hlc implements a hybrid logical clock used to order writes
+// across regions. Timestamps follow physical time closely but never go
+// backwards and are ordered consistently with causality between nodes.
+package hlc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMaxOffset is how far ahead of the local physical time a remote
+// timestamp may be unless SetMaxOffset says otherwise.
+const DefaultMaxOffset = 500 * time.Millisecond
+
+// ErrClockOffset is returned by Update for remote timestamps too far ahead
+// of the local physical time, which would drag the clock along.
+var ErrClockOffset = errors.New("hlc: remote timestamp beyond the maximum clock offset")
+
+// Timestamp is a reading of a hybrid logical clock. Region identifies the
+// writer and breaks ties between timestamps taken in different regions.
+type Timestamp struct {
+	WallTime int64  `json:"wall_time"`
+	Logical  uint32 `json:"logical"`
+	Region   uint   `json:"region"`
+}
+
+// IsZero reports whether t was never set.
+func (t Timestamp) IsZero() bool {
+	return t.WallTime == 0 && t.Logical == 0
+}
+
+// Compare returns -1, 0 or 1 depending on whether t happened before, at
+// the same time as, or after o.
+func (t Timestamp) Compare(o Timestamp) int {
+	switch {
+	case t.WallTime < o.WallTime:
+		return -1
+	case t.WallTime > o.WallTime:
+		return 1
+	case t.Logical < o.Logical:
+		return -1
+	case t.Logical > o.Logical:
+		return 1
+	case t.Region < o.Region:
+		return -1
+	case t.Region > o.Region:
+		return 1
+	}
+	return 0
+}
+
+// Clock hands out timestamps for a single region.
+type Clock struct {
+	mu sync.Mutex
+
+	region uint
+
+	// physical time source, in nanoseconds
+	physical func() int64
+
+	// how far ahead of physical time remote timestamps may be, 0 for no limit
+	maxOffset time.Duration
+
+	last Timestamp
+}
+
+// NewClock returns a clock stamping timestamps with region.
+func NewClock(region uint) *Clock {
+	return &Clock{
+		region: region,
+		physical: func() int64 {
+			return time.Now().UnixNano()
+		},
+		maxOffset: DefaultMaxOffset,
+	}
+}
+
+// SetMaxOffset sets how far ahead of the local physical time a remote
+// timestamp may be; 0 accepts any timestamp.
+func (c *Clock) SetMaxOffset(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxOffset = d
+}
+
+// Now returns a timestamp greater than any previously returned or observed.
+func (c *Clock) Now() Timestamp {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pt := c.physical()
+	if pt > c.last.WallTime {
+		c.last = Timestamp{WallTime: pt}
+	} else {
+		c.last.Logical++
+	}
+	return Timestamp{WallTime: c.last.WallTime, Logical: c.last.Logical, Region: c.region}
+}
+
+// Until returns how long until ts is no longer beyond the maximum offset,
+// 0 if Update accepts it already.
+func (c *Clock) Until(ts Timestamp) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ahead := time.Duration(ts.WallTime - c.physical())
+	if c.maxOffset <= 0 || ahead <= c.maxOffset {
+		return 0
+	}
+	return ahead - c.maxOffset
+}
+
+// Update advances the clock past a timestamp received from another node so
+// that later local writes are ordered after it. A timestamp more than the
+// maximum offset ahead of the local physical time leaves the clock as is
+// and returns an error wrapping ErrClockOffset.
+func (c *Clock) Update(remote Timestamp) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pt := c.physical()
+	if c.maxOffset > 0 && time.Duration(remote.WallTime-pt) > c.maxOffset {
+		return fmt.Errorf("%w: %s ahead of region %d", ErrClockOffset,
+			time.Duration(remote.WallTime-pt), c.region)
+	}
+	switch {
+	case pt > c.last.WallTime && pt > remote.WallTime:
+		c.last = Timestamp{WallTime: pt}
+	case c.last.WallTime == remote.WallTime:
+		if remote.Logical > c.last.Logical {
+			c.last.Logical = remote.Logical
+		}
+		c.last.Logical++
+	case c.last.WallTime > remote.WallTime:
+		c.last.Logical++
+	default:
+		c.last = Timestamp{WallTime: remote.WallTime, Logical: remote.Logical + 1}
+	}
+	return nil
+}
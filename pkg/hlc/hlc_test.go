@@ -0,0 +1,155 @@
+package hlc
+
+import (
+	"errors"
+	"testing"
+)
+
+// newTestClock returns a clock of region 1 reading the physical time from *pt.
+func newTestClock(pt *int64) *Clock {
+	c := NewClock(1)
+	c.physical = func() int64 { return *pt }
+	return c
+}
+
+func TestNow(t *testing.T) {
+	var pt int64
+	c := newTestClock(&pt)
+	for _, tt := range []struct {
+		name     string
+		physical int64
+		want     Timestamp
+	}{
+		{"physical time", 100, Timestamp{WallTime: 100, Region: 1}},
+		{"same physical time", 100, Timestamp{WallTime: 100, Logical: 1, Region: 1}},
+		{"wall time going backwards", 50, Timestamp{WallTime: 100, Logical: 2, Region: 1}},
+		{"still behind", 99, Timestamp{WallTime: 100, Logical: 3, Region: 1}},
+		{"caught up", 101, Timestamp{WallTime: 101, Region: 1}},
+	} {
+		pt = tt.physical
+		if got := c.Now(); got != tt.want {
+			t.Errorf("%s: Now() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	local := Timestamp{WallTime: 1000, Logical: 5}
+	for _, tt := range []struct {
+		name     string
+		physical int64
+		remote   Timestamp
+		want     Timestamp
+		err      error
+	}{
+		{
+			name:     "remote ahead",
+			physical: 900,
+			remote:   Timestamp{WallTime: 1200, Logical: 2, Region: 2},
+			want:     Timestamp{WallTime: 1200, Logical: 3},
+		},
+		{
+			name:     "remote equal, higher logical",
+			physical: 900,
+			remote:   Timestamp{WallTime: 1000, Logical: 7, Region: 2},
+			want:     Timestamp{WallTime: 1000, Logical: 8},
+		},
+		{
+			name:     "remote equal, lower logical",
+			physical: 900,
+			remote:   Timestamp{WallTime: 1000, Logical: 3, Region: 2},
+			want:     Timestamp{WallTime: 1000, Logical: 6},
+		},
+		{
+			name:     "remote behind",
+			physical: 900,
+			remote:   Timestamp{WallTime: 500, Logical: 9, Region: 2},
+			want:     Timestamp{WallTime: 1000, Logical: 6},
+		},
+		{
+			name:     "physical time ahead of both",
+			physical: 3000,
+			remote:   Timestamp{WallTime: 1200, Logical: 2, Region: 2},
+			want:     Timestamp{WallTime: 3000},
+		},
+		{
+			name:     "remote within the maximum offset",
+			physical: 1000,
+			remote:   Timestamp{WallTime: 1000 + int64(DefaultMaxOffset), Region: 2},
+			want:     Timestamp{WallTime: 1000 + int64(DefaultMaxOffset), Logical: 1},
+		},
+		{
+			name:     "remote beyond the maximum offset",
+			physical: 1000,
+			remote:   Timestamp{WallTime: 1001 + int64(DefaultMaxOffset), Region: 2},
+			want:     local,
+			err:      ErrClockOffset,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pt := tt.physical
+			c := newTestClock(&pt)
+			c.last = local
+			err := c.Update(tt.remote)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Update(%+v) = %v, want %v", tt.remote, err, tt.err)
+			}
+			if c.last != tt.want {
+				t.Errorf("Update(%+v) left the clock at %+v, want %+v", tt.remote, c.last, tt.want)
+			}
+			// later local timestamps follow the remote one
+			if now := c.Now(); now.Compare(tt.remote) <= 0 && tt.err == nil {
+				t.Errorf("Now() = %+v after Update(%+v), want a later timestamp", now, tt.remote)
+			}
+		})
+	}
+}
+
+func TestUpdateWithoutMaxOffset(t *testing.T) {
+	pt := int64(1000)
+	c := newTestClock(&pt)
+	c.SetMaxOffset(0)
+	remote := Timestamp{WallTime: 1000 + 10*int64(DefaultMaxOffset), Region: 2}
+	if err := c.Update(remote); err != nil {
+		t.Fatal(err)
+	}
+	if c.last.WallTime != remote.WallTime {
+		t.Errorf("clock at %+v, want it to follow %+v", c.last, remote)
+	}
+}
+
+func TestUntil(t *testing.T) {
+	pt := int64(1000)
+	c := newTestClock(&pt)
+	remote := Timestamp{WallTime: 1000 + int64(DefaultMaxOffset) + 300, Region: 2}
+	if got := c.Until(remote); got != 300 {
+		t.Fatalf("Until() = %s, want 300ns", got)
+	}
+	pt += 300
+	if got := c.Until(remote); got != 0 {
+		t.Fatalf("Until() = %s once within the offset, want 0", got)
+	}
+	if err := c.Update(remote); err != nil {
+		t.Fatalf("Update() = %v once Until() returned 0", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b Timestamp
+		want int
+	}{
+		{"wall time", Timestamp{WallTime: 1, Logical: 9, Region: 9}, Timestamp{WallTime: 2}, -1},
+		{"logical", Timestamp{WallTime: 2, Logical: 1, Region: 9}, Timestamp{WallTime: 2, Logical: 2}, -1},
+		{"region breaks ties", Timestamp{WallTime: 2, Logical: 2, Region: 1}, Timestamp{WallTime: 2, Logical: 2, Region: 2}, -1},
+		{"equal", Timestamp{WallTime: 2, Logical: 2, Region: 2}, Timestamp{WallTime: 2, Logical: 2, Region: 2}, 0},
+	} {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%s: %+v.Compare(%+v) = %d, want %d", tt.name, tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.b.Compare(tt.a); got != -tt.want {
+			t.Errorf("%s: %+v.Compare(%+v) = %d, want %d", tt.name, tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package logging defines the leveled, structured logger used by the
+// replicator and storage packages.
+//
+// Messages come with key/value pairs, as in slog and zap's sugared logger:
+//
+//	logger.Info("joined cluster", "seeds", 2, "members", 3)
+//
+// A *slog.Logger satisfies Logger as is; zap and other libraries can be
+// plugged in with FromFuncs, e.g. FromFuncs(s.Debugw, s.Infow, s.Warnw, s.Errorw)
+// for a *zap.SugaredLogger.
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger logs messages at four levels, each with alternating key/value pairs.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// Level is the minimum severity written by the standard logger.
+type Level int
+
+const (
+	LevelDebug Level = iota - 1
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return "level(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ParseLevel parses debug, info, warn or error, case insensitively.
+func ParseLevel(s string) (Level, error) {
+	for _, l := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+		if strings.EqualFold(s, l.String()) {
+			return l, nil
+		}
+	}
+	if strings.EqualFold(s, "warning") {
+		return LevelWarn, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", s)
+}
+
+// stdLogger writes one logfmt line per message:
+//
+//	time=2021-06-01T10:00:00.000Z level=info msg="joined cluster" node=a region=1
+type stdLogger struct {
+	mu    *sync.Mutex
+	w     io.Writer
+	level Level
+	attrs []interface{}
+}
+
+// Default returns the Logger used when none is configured: info and above
+// written to stderr.
+func Default() Logger {
+	return New(os.Stderr, LevelInfo)
+}
+
+// New returns a Logger writing messages at level or above to w.
+func New(w io.Writer, level Level) Logger {
+	return &stdLogger{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+func (l *stdLogger) Debug(msg string, kv ...interface{}) { l.log(LevelDebug, msg, kv) }
+func (l *stdLogger) Info(msg string, kv ...interface{})  { l.log(LevelInfo, msg, kv) }
+func (l *stdLogger) Warn(msg string, kv ...interface{})  { l.log(LevelWarn, msg, kv) }
+func (l *stdLogger) Error(msg string, kv ...interface{}) { l.log(LevelError, msg, kv) }
+
+func (l *stdLogger) log(level Level, msg string, kv []interface{}) {
+	if level < l.level {
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString("time=")
+	buf.WriteString(time.Now().UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	buf.WriteString(" level=")
+	buf.WriteString(level.String())
+	buf.WriteString(" msg=")
+	writeValue(&buf, msg)
+	writePairs(&buf, l.attrs)
+	writePairs(&buf, kv)
+	buf.WriteByte('\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.w.Write(buf.Bytes())
+}
+
+func writePairs(buf *bytes.Buffer, kv []interface{}) {
+	for i := 0; i < len(kv); i += 2 {
+		buf.WriteByte(' ')
+		if i+1 == len(kv) {
+			// a lone value, as slog does
+			buf.WriteString("!BADKEY=")
+			writeValue(buf, kv[i])
+			return
+		}
+		buf.WriteString(fmt.Sprint(kv[i]))
+		buf.WriteByte('=')
+		writeValue(buf, kv[i+1])
+	}
+}
+
+func writeValue(buf *bytes.Buffer, v interface{}) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case time.Duration:
+		s = v.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"=") {
+		s = strconv.Quote(s)
+	}
+	buf.WriteString(s)
+}
+
+// With returns a Logger adding the given key/value pairs to every message.
+func With(l Logger, keysAndValues ...interface{}) Logger {
+	if len(keysAndValues) == 0 {
+		return l
+	}
+	switch l := l.(type) {
+	case *stdLogger:
+		c := *l
+		c.attrs = append(append([]interface{}{}, l.attrs...), keysAndValues...)
+		return &c
+	case *withLogger:
+		return &withLogger{
+			next:  l.next,
+			attrs: append(append([]interface{}{}, l.attrs...), keysAndValues...),
+		}
+	case nopLogger:
+		return l
+	}
+	return &withLogger{next: l, attrs: keysAndValues}
+}
+
+// withLogger adds attributes to a Logger it knows nothing about.
+type withLogger struct {
+	next  Logger
+	attrs []interface{}
+}
+
+func (l *withLogger) with(kv []interface{}) []interface{} {
+	return append(append([]interface{}{}, l.attrs...), kv...)
+}
+
+func (l *withLogger) Debug(msg string, kv ...interface{}) { l.next.Debug(msg, l.with(kv)...) }
+func (l *withLogger) Info(msg string, kv ...interface{})  { l.next.Info(msg, l.with(kv)...) }
+func (l *withLogger) Warn(msg string, kv ...interface{})  { l.next.Warn(msg, l.with(kv)...) }
+func (l *withLogger) Error(msg string, kv ...interface{}) { l.next.Error(msg, l.with(kv)...) }
+
+// FromFuncs builds a Logger out of one function per level, e.g. the
+// Debugw, Infow, Warnw and Errorw methods of a *zap.SugaredLogger.
+func FromFuncs(debug, info, warn, error func(msg string, keysAndValues ...interface{})) Logger {
+	return funcLogger{debug: debug, info: info, warn: warn, error: error}
+}
+
+type funcLogger struct {
+	debug, info, warn, error func(string, ...interface{})
+}
+
+func (l funcLogger) Debug(msg string, kv ...interface{}) { l.debug(msg, kv...) }
+func (l funcLogger) Info(msg string, kv ...interface{})  { l.info(msg, kv...) }
+func (l funcLogger) Warn(msg string, kv ...interface{})  { l.warn(msg, kv...) }
+func (l funcLogger) Error(msg string, kv ...interface{}) { l.error(msg, kv...) }
+
+// Nop returns a Logger discarding everything.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// Payload wraps event data so that it never reaches the logs: it is
+// rendered with its size and a short hash, enough to tell two payloads
+// apart without disclosing them.
+type Payload []byte
+
+// String returns the redacted form of p.
+func (p Payload) String() string {
+	h := fnv.New32a()
+	h.Write(p)
+	return fmt.Sprintf("[redacted %d bytes %08x]", len(p), h.Sum32())
+}
+
+// GoString keeps %#v from printing the bytes.
+func (p Payload) GoString() string {
+	return "logging.Payload(" + strconv.Quote(p.String()) + ")"
+}
+
+// MarshalText is used by slog's text handler and other encoders.
+func (p Payload) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// MarshalJSON is used by JSON handlers.
+func (p Payload) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(p.String())), nil
+}
+
+// StdLog returns a standard library logger writing through l, for
+// dependencies such as memberlist that take a *log.Logger. Lines starting
+// with [DEBUG], [INFO], [WARN] or [ERR] are logged at that level; other
+// lines at info.
+func StdLog(l Logger, component string) *log.Logger {
+	return log.New(&lineWriter{logger: l, component: component}, "", 0)
+}
+
+type lineWriter struct {
+	logger    Logger
+	component string
+}
+
+var linePrefixes = []struct {
+	prefix string
+	log    func(Logger) func(string, ...interface{})
+}{
+	{"[DEBUG]", func(l Logger) func(string, ...interface{}) { return l.Debug }},
+	{"[INFO]", func(l Logger) func(string, ...interface{}) { return l.Info }},
+	{"[WARN]", func(l Logger) func(string, ...interface{}) { return l.Warn }},
+	{"[ERR]", func(l Logger) func(string, ...interface{}) { return l.Error }},
+	{"[ERROR]", func(l Logger) func(string, ...interface{}) { return l.Error }},
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	line := strings.TrimSpace(string(p))
+	logf := w.logger.Info
+	for _, lp := range linePrefixes {
+		if strings.HasPrefix(line, lp.prefix) {
+			line = strings.TrimSpace(strings.TrimPrefix(line, lp.prefix))
+			logf = lp.log(w.logger)
+			break
+		}
+	}
+	// memberlist prefixes its own lines with its name
+	line = strings.TrimPrefix(line, w.component+": ")
+	logf(line, "component", w.component)
+	return len(p), nil
+}
@@ -0,0 +1,202 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// timestamp matches the time every line starts with.
+var timestamp = regexp.MustCompile(`^time=\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{3}Z `)
+
+// lines returns what was logged to buf, one line per message, without the
+// timestamps.
+func lines(t *testing.T, buf *bytes.Buffer) []string {
+	t.Helper()
+	var out []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if !timestamp.MatchString(line) {
+			t.Fatalf("line %q does not start with a timestamp", line)
+		}
+		out = append(out, timestamp.ReplaceAllString(line, ""))
+	}
+	buf.Reset()
+	return out
+}
+
+func TestLogfmt(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		msg  string
+		kv   []interface{}
+		want string
+	}{
+		{"plain", "joined", []interface{}{"members", 3}, "level=info msg=joined members=3"},
+		{"quoted message", "joined cluster", nil, `level=info msg="joined cluster"`},
+		{"empty value", "m", []interface{}{"seed", ""}, `level=info msg=m seed=""`},
+		{"quoted values", "m", []interface{}{"addr", "a b", "q", `say "hi"`, "eq", "k=v"},
+			`level=info msg=m addr="a b" q="say \"hi\"" eq="k=v"`},
+		{"newline", "m", []interface{}{"line", "one\ntwo"}, `level=info msg=m line="one\ntwo"`},
+		{"error", "m", []interface{}{"error", errors.New("connection refused")}, `level=info msg=m error="connection refused"`},
+		{"duration", "m", []interface{}{"took", 1500 * time.Millisecond}, "level=info msg=m took=1.5s"},
+		{"non-string key", "m", []interface{}{42, true}, "level=info msg=m 42=true"},
+		{"lone value", "m", []interface{}{"a", 1, "dangling"}, "level=info msg=m a=1 !BADKEY=dangling"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			New(&buf, LevelInfo).Info(tt.msg, tt.kv...)
+			if got := lines(t, &buf); len(got) != 1 || got[0] != tt.want {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelWarn)
+	l.Debug("d")
+	l.Info("i")
+	l.Warn("w")
+	l.Error("e")
+	want := []string{"level=warn msg=w", "level=error msg=e"}
+	if got := lines(t, &buf); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("logged %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct {
+		s    string
+		want Level
+	}{
+		{"debug", LevelDebug}, {"INFO", LevelInfo}, {"warn", LevelWarn}, {"Warning", LevelWarn}, {"error", LevelError},
+	} {
+		if got, err := ParseLevel(tt.s); err != nil || got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("parsed an unknown level")
+	}
+}
+
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	root := New(&buf, LevelDebug)
+	node := With(root, "node", "a")
+	region := With(node, "region", 1)
+	other := With(node, "region", 2)
+
+	root.Info("root")
+	node.Debug("node", "k", "v")
+	region.Warn("region", "k", "v")
+	other.Error("other")
+	want := []string{
+		"level=info msg=root",
+		"level=debug msg=node node=a k=v",
+		"level=warn msg=region node=a region=1 k=v",
+		"level=error msg=other node=a region=2",
+	}
+	if got := lines(t, &buf); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("logged\n%q\nwant\n%q", got, want)
+	}
+	if With(root) != root {
+		t.Error("With without attributes wrapped the logger")
+	}
+
+	// loggers built from functions get the attributes first
+	var calls []string
+	record := func(level string) func(string, ...interface{}) {
+		return func(msg string, kv ...interface{}) {
+			calls = append(calls, fmt.Sprint(level, " ", msg, kv))
+		}
+	}
+	funcs := FromFuncs(record("debug"), record("info"), record("warn"), record("error"))
+	l := With(With(funcs, "node", "a"), "region", 1)
+	l.Debug("d")
+	l.Info("i", "k", "v")
+	l.Warn("w")
+	l.Error("e")
+	wantCalls := []string{
+		"debug d[node a region 1]",
+		"info i[node a region 1 k v]",
+		"warn w[node a region 1]",
+		"error e[node a region 1]",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(wantCalls) {
+		t.Errorf("called\n%q\nwant\n%q", calls, wantCalls)
+	}
+}
+
+func TestStdLog(t *testing.T) {
+	var buf bytes.Buffer
+	l := StdLog(New(&buf, LevelDebug), "memberlist")
+	l.Print("[DEBUG] memberlist: probing b")
+	l.Print("[WARN] memberlist: refuting a suspect message")
+	l.Print("[ERR] memberlist: failed to send")
+	l.Print("no prefix")
+	want := []string{
+		`level=debug msg="probing b" component=memberlist`,
+		`level=warn msg="refuting a suspect message" component=memberlist`,
+		`level=error msg="failed to send" component=memberlist`,
+		`level=info msg="no prefix" component=memberlist`,
+	}
+	if got := lines(t, &buf); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("logged\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestPayloadIsRedacted(t *testing.T) {
+	secret := "card=4111111111111111"
+	p := Payload(secret)
+	h := fnv.New32a()
+	h.Write([]byte(secret))
+	want := fmt.Sprintf("[redacted %d bytes %08x]", len(secret), h.Sum32())
+	if p.String() != want {
+		t.Fatalf("String() = %q, want %q", p.String(), want)
+	}
+	if Payload("card=4111111111111112").String() == want {
+		t.Error("two payloads of the same size rendered alike")
+	}
+
+	var buf bytes.Buffer
+	With(New(&buf, LevelDebug), "payload", p).Info("merged", "data", p)
+	logged := buf.String()
+
+	js, err := json.Marshal(map[string]interface{}{"data": p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := p.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name, got, want string
+	}{
+		{"logfmt", logged, `data="` + want + `"`},
+		{"json", string(js), `{"data":"` + want + `"}`},
+		{"text", string(text), want},
+		{"%v", fmt.Sprintf("%v", p), want},
+		{"%s", fmt.Sprintf("%s", p), want},
+		{"%q", fmt.Sprintf("%q", p), `"` + want + `"`},
+		{"%x", fmt.Sprintf("%x", p), fmt.Sprintf("%x", want)},
+		{"%#v", fmt.Sprintf("%#v", p), "logging.Payload(" + fmt.Sprintf("%q", want) + ")"},
+		{"%+v in a struct", fmt.Sprintf("%+v", struct{ Data Payload }{p}), "{Data:" + want + "}"},
+	} {
+		if !strings.Contains(tt.got, tt.want) {
+			t.Errorf("%s: %q, want it to contain %q", tt.name, tt.got, tt.want)
+		}
+		if strings.Contains(tt.got, secret) || strings.Contains(tt.got, fmt.Sprintf("%x", secret)) {
+			t.Errorf("%s: %q discloses the payload", tt.name, tt.got)
+		}
+	}
+}
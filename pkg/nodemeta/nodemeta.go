@@ -0,0 +1,379 @@
+// Package nodemeta defines the metadata every node gossips about itself
+// through memberlist, and decodes the metadata of peers.
+//
+// The encoding is compact and versioned: a magic byte, the schema version,
+// then the fields of that version in order. A newer schema version only
+// appends fields, so a node decodes what it knows of newer peers' metadata
+// and ignores the rest.
+package nodemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+)
+
+const (
+	// magic starts every encoded metadata, telling it apart from the
+	// gob-encoded map older nodes shared.
+	magic byte = 0xa7
+
+	// SchemaVersion is the version of the encoding written by Encode.
+	// Version 2 appended the region registry.
+	SchemaVersion = 2
+)
+
+// RoleReplica is the role of a node storing and replicating events.
+const RoleReplica = "replica"
+
+var (
+	// ErrTooLarge is returned by Encode when even the required fields do not fit.
+	ErrTooLarge = errors.New("nodemeta: metadata does not fit in the size limit")
+
+	errTruncated = errors.New("nodemeta: truncated metadata")
+)
+
+// Meta describes a node to its peers.
+type Meta struct {
+	// Schema is the schema version the metadata was encoded with; it is
+	// set by Decode and ignored by Encode.
+	Schema int
+
+	// Region is the region the node replicates for.
+	Region uint
+
+	// Protocol is the replication protocol version the node speaks.
+	Protocol uint
+
+	// Role is what the node does in the cluster, RoleReplica by default.
+	Role string
+
+	// BuildVersion is the version of the software the node runs.
+	BuildVersion string
+
+	// APIAddr is the host:port serving the replicator API. The host is
+	// empty when the node serves on every interface; see APIEndpoint.
+	APIAddr string
+
+	// Extra holds free-form entries, e.g. a node announcing it is leaving.
+	// They are the first dropped when the metadata does not fit.
+	Extra map[string]string
+
+	// Registry is the region registry the node follows.
+	Registry Registry
+}
+
+// Registry lists the regions the operator declared. Every node gossips the
+// registry it follows and merges the ones of its peers, see Merge.
+type Registry struct {
+	// Version is the highest version of the changes listed; 0 is the
+	// registry a node was configured with.
+	Version uint64
+
+	// Regions must commit an event, whether their nodes are up or not.
+	Regions []uint
+
+	// Retired regions were decommissioned: they count only while some of
+	// their nodes are still alive.
+	Retired []uint
+
+	// Changed is the version at which each region was last registered or
+	// retired, 0 if missing.
+	Changed map[uint]uint64
+}
+
+// Merge returns the registry following both r and other. For every region,
+// the latest change wins; changes made concurrently on different nodes can
+// get the same version, and a retirement then wins over a registration.
+// Nothing either registry lists is lost, whichever order nodes merge them.
+func (r Registry) Merge(other Registry) Registry {
+	type change struct {
+		version uint64
+		retired bool
+	}
+	changes := make(map[uint]change)
+	for _, reg := range []Registry{r, other} {
+		for i, regions := range [][]uint{reg.Regions, reg.Retired} {
+			retired := i == 1
+			for _, region := range regions {
+				c, ok := changes[region]
+				v := reg.Changed[region]
+				if !ok || v > c.version || (v == c.version && retired) {
+					changes[region] = change{version: v, retired: retired}
+				}
+			}
+		}
+	}
+
+	merged := Registry{Version: r.Version, Changed: make(map[uint]uint64, len(changes))}
+	if other.Version > merged.Version {
+		merged.Version = other.Version
+	}
+	for region, c := range changes {
+		if c.retired {
+			merged.Retired = append(merged.Retired, region)
+		} else {
+			merged.Regions = append(merged.Regions, region)
+		}
+		merged.Changed[region] = c.version
+	}
+	sortUints(merged.Regions)
+	sortUints(merged.Retired)
+	return merged
+}
+
+// Equal reports whether r and other list the same changes.
+func (r Registry) Equal(other Registry) bool {
+	if r.Version != other.Version || !equalUints(r.Regions, other.Regions) || !equalUints(r.Retired, other.Retired) {
+		return false
+	}
+	for _, regions := range [][]uint{r.Regions, r.Retired} {
+		for _, region := range regions {
+			if r.Changed[region] != other.Changed[region] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func sortUints(vs []uint) {
+	sort.Slice(vs, func(i, j int) bool { return vs[i] < vs[j] })
+}
+
+func equalUints(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// APIEndpoint returns the address to reach the API of the node, using the
+// gossip host when the node serves on every interface.
+func (m Meta) APIEndpoint(gossipHost string) string {
+	if m.APIAddr == "" {
+		return ""
+	}
+	host, port, err := net.SplitHostPort(m.APIAddr)
+	if err != nil {
+		return m.APIAddr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = gossipHost
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// Encode returns the encoded metadata, at most limit bytes long. When it
+// does not fit, the extra entries are dropped and then the build version,
+// which are informational; ErrTooLarge is returned if it still does not fit.
+func (m Meta) Encode(limit int) ([]byte, error) {
+	b := m.encode()
+	if len(b) <= limit {
+		return b, nil
+	}
+	m.Extra = nil
+	b = m.encode()
+	if len(b) <= limit {
+		return b, nil
+	}
+	m.BuildVersion = ""
+	b = m.encode()
+	if len(b) <= limit {
+		return b, nil
+	}
+	return nil, ErrTooLarge
+}
+
+func (m Meta) encode() []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(magic)
+	putUvarint(&buf, SchemaVersion)
+	putUvarint(&buf, uint64(m.Region))
+	putUvarint(&buf, uint64(m.Protocol))
+	putString(&buf, m.Role)
+	putString(&buf, m.BuildVersion)
+	putString(&buf, m.APIAddr)
+
+	keys := make([]string, 0, len(m.Extra))
+	for k := range m.Extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	putUvarint(&buf, uint64(len(keys)))
+	for _, k := range keys {
+		putString(&buf, k)
+		putString(&buf, m.Extra[k])
+	}
+
+	putUvarint(&buf, m.Registry.Version)
+	for _, regions := range [][]uint{m.Registry.Regions, m.Registry.Retired} {
+		putUvarint(&buf, uint64(len(regions)))
+		for _, r := range regions {
+			putUvarint(&buf, uint64(r))
+			putUvarint(&buf, m.Registry.Changed[r])
+		}
+	}
+	return buf.Bytes()
+}
+
+// Decode decodes the metadata a peer shared. Metadata gossiped by older
+// nodes, a gob-encoded map, is decoded too; its schema version is 0.
+func Decode(b []byte) (Meta, error) {
+	if len(b) == 0 {
+		return Meta{}, nil
+	}
+	if b[0] != magic {
+		return decodeLegacy(b)
+	}
+
+	r := bytes.NewReader(b[1:])
+	var m Meta
+	schema, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Meta{}, errTruncated
+	}
+	if schema == 0 {
+		return Meta{}, fmt.Errorf("nodemeta: invalid schema version 0")
+	}
+	m.Schema = int(schema)
+
+	region, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Meta{}, errTruncated
+	}
+	m.Region = uint(region)
+	protocol, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Meta{}, errTruncated
+	}
+	m.Protocol = uint(protocol)
+	for _, s := range []*string{&m.Role, &m.BuildVersion, &m.APIAddr} {
+		*s, err = readString(r)
+		if err != nil {
+			return Meta{}, err
+		}
+	}
+
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Meta{}, errTruncated
+	}
+	if n > uint64(r.Len()) {
+		return Meta{}, errTruncated
+	}
+	if n > 0 {
+		m.Extra = make(map[string]string, n)
+	}
+	for i := uint64(0); i < n; i++ {
+		k, err := readString(r)
+		if err != nil {
+			return Meta{}, err
+		}
+		v, err := readString(r)
+		if err != nil {
+			return Meta{}, err
+		}
+		m.Extra[k] = v
+	}
+	if schema < 2 {
+		return m, nil
+	}
+
+	m.Registry.Version, err = binary.ReadUvarint(r)
+	if err != nil {
+		return Meta{}, errTruncated
+	}
+	for _, regions := range []*[]uint{&m.Registry.Regions, &m.Registry.Retired} {
+		*regions, err = readRegions(r, &m.Registry.Changed)
+		if err != nil {
+			return Meta{}, err
+		}
+	}
+	// fields appended by newer schema versions are left unread
+	return m, nil
+}
+
+// decodeLegacy decodes the map of apiPort and region older nodes shared.
+func decodeLegacy(b []byte) (Meta, error) {
+	var md map[string]string
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&md)
+	if err != nil {
+		return Meta{}, fmt.Errorf("nodemeta: unknown metadata encoding: %w", err)
+	}
+	m := Meta{Role: RoleReplica}
+	if region, err := strconv.ParseUint(md["region"], 10, 0); err == nil {
+		m.Region = uint(region)
+	}
+	if port := md["apiPort"]; port != "" {
+		m.APIAddr = net.JoinHostPort("", port)
+	}
+	delete(md, "region")
+	delete(md, "apiPort")
+	if len(md) > 0 {
+		m.Extra = md
+	}
+	return m, nil
+}
+
+func putUvarint(buf *bytes.Buffer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func putString(buf *bytes.Buffer, s string) {
+	putUvarint(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+// readRegions reads a list of regions, each followed by the version it
+// changed at, which is recorded in changed.
+func readRegions(r *bytes.Reader, changed *map[uint]uint64) ([]uint, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return nil, errTruncated
+	}
+	var regions []uint
+	for i := uint64(0); i < n; i++ {
+		region, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errTruncated
+		}
+		version, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errTruncated
+		}
+		regions = append(regions, uint(region))
+		if version > 0 {
+			if *changed == nil {
+				*changed = make(map[uint]uint64)
+			}
+			(*changed)[uint(region)] = version
+		}
+	}
+	return regions, nil
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return "", errTruncated
+	}
+	b := make([]byte, n)
+	_, err = io.ReadFull(r, b)
+	if err != nil {
+		return "", errTruncated
+	}
+	return string(b), nil
+}
@@ -0,0 +1,206 @@
+package nodemeta
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testMeta() Meta {
+	return Meta{
+		Region:       2,
+		Protocol:     3,
+		Role:         RoleReplica,
+		BuildVersion: "v1.4.0",
+		APIAddr:      ":9000",
+		Extra:        map[string]string{"leaving": "true", "zone": "eu-west-1a"},
+		Registry: Registry{
+			Version: 7,
+			Regions: []uint{1, 2},
+			Retired: []uint{3},
+			Changed: map[uint]uint64{2: 5, 3: 7},
+		},
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	m := testMeta()
+	b, err := m.Encode(512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Schema = SchemaVersion
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("decoded %+v, want %+v", got, m)
+	}
+}
+
+func TestEncodeSizeLimit(t *testing.T) {
+	m := testMeta()
+	full := len(m.encode())
+	noExtra := m
+	noExtra.Extra = nil
+	minimal := noExtra
+	minimal.BuildVersion = ""
+
+	for _, tt := range []struct {
+		name  string
+		limit int
+		want  Meta
+		err   error
+	}{
+		{"fits", full, m, nil},
+		{"extra dropped", full - 1, noExtra, nil},
+		{"build version dropped", len(noExtra.encode()) - 1, minimal, nil},
+		{"required fields only", len(minimal.encode()), minimal, nil},
+		{"too small", len(minimal.encode()) - 1, Meta{}, ErrTooLarge},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := m.Encode(tt.limit)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Encode(%d) = %v, want %v", tt.limit, err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if len(b) > tt.limit {
+				t.Fatalf("encoded %d bytes, limit %d", len(b), tt.limit)
+			}
+			got, err := Decode(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.want.Schema = SchemaVersion
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decoded %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	b := testMeta().encode()
+	for n := 1; n < len(b); n++ {
+		if m, err := Decode(b[:n]); err == nil {
+			t.Errorf("decoded the first %d of %d bytes as %+v, want an error", n, len(b), m)
+		}
+	}
+}
+
+func TestDecodeLegacy(t *testing.T) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(map[string]string{
+		"region":  "3",
+		"apiPort": "9002",
+		"zone":    "us-east-1b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Meta{
+		Region:  3,
+		Role:    RoleReplica,
+		APIAddr: ":9002",
+		Extra:   map[string]string{"zone": "us-east-1b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+
+	if _, err := Decode([]byte("neither gob nor the current encoding")); err == nil {
+		t.Error("decoded garbage")
+	}
+}
+
+func TestDecodeNewerSchema(t *testing.T) {
+	m := testMeta()
+	b := m.encode()
+	if b[1] != SchemaVersion {
+		t.Fatalf("schema version encoded as %#x", b[1])
+	}
+	// a newer node appends fields this version does not know
+	b[1] = SchemaVersion + 1
+	b = append(b, 0x05, 'h', 'e', 'l', 'l', 'o', 0x2a)
+
+	got, err := Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Schema = SchemaVersion + 1
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("decoded %+v, want %+v", got, m)
+	}
+}
+
+func TestRegistryMerge(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		a, b, want Registry
+	}{
+		{
+			"configured regions",
+			Registry{Regions: []uint{1, 2}},
+			Registry{Regions: []uint{2, 3}},
+			Registry{Regions: []uint{1, 2, 3}, Changed: map[uint]uint64{}},
+		},
+		{
+			"concurrent registrations",
+			Registry{Version: 1, Regions: []uint{1, 7}, Changed: map[uint]uint64{7: 1}},
+			Registry{Version: 1, Regions: []uint{1, 8}, Changed: map[uint]uint64{8: 1}},
+			Registry{Version: 1, Regions: []uint{1, 7, 8}, Changed: map[uint]uint64{7: 1, 8: 1}},
+		},
+		{
+			"retirement wins a tie",
+			Registry{Version: 1, Regions: []uint{1, 3}, Changed: map[uint]uint64{3: 1}},
+			Registry{Version: 1, Regions: []uint{1}, Retired: []uint{3}, Changed: map[uint]uint64{3: 1}},
+			Registry{Version: 1, Regions: []uint{1}, Retired: []uint{3}, Changed: map[uint]uint64{3: 1}},
+		},
+		{
+			"latest change wins",
+			Registry{Version: 1, Regions: []uint{1}, Retired: []uint{3}, Changed: map[uint]uint64{3: 1}},
+			Registry{Version: 2, Regions: []uint{1, 3}, Changed: map[uint]uint64{3: 2}},
+			Registry{Version: 2, Regions: []uint{1, 3}, Changed: map[uint]uint64{3: 2}},
+		},
+		{
+			"changes of other regions are kept",
+			Registry{Version: 1, Regions: []uint{1, 7}, Changed: map[uint]uint64{7: 1}},
+			Registry{Version: 2, Regions: []uint{1}, Retired: []uint{4}, Changed: map[uint]uint64{4: 2}},
+			Registry{Version: 2, Regions: []uint{1, 7}, Retired: []uint{4}, Changed: map[uint]uint64{7: 1, 4: 2}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, got := range []Registry{tt.a.Merge(tt.b), tt.b.Merge(tt.a)} {
+				if !got.Equal(tt.want) {
+					t.Errorf("merged %+v, want %+v", got, tt.want)
+				}
+				if again := got.Merge(tt.a).Merge(tt.b); !again.Equal(got) {
+					t.Errorf("merging again changed %+v to %+v", got, again)
+				}
+			}
+		})
+	}
+
+	r := Registry{Version: 2, Regions: []uint{1}, Changed: map[uint]uint64{1: 2}}
+	for _, other := range []Registry{
+		{Version: 3, Regions: []uint{1}, Changed: map[uint]uint64{1: 2}},
+		{Version: 2, Regions: []uint{1, 2}, Changed: map[uint]uint64{1: 2}},
+		{Version: 2, Regions: []uint{1}, Changed: map[uint]uint64{1: 1}},
+	} {
+		if r.Equal(other) {
+			t.Errorf("%+v equals %+v", r, other)
+		}
+	}
+	if !r.Equal(Registry{Version: 2, Regions: []uint{1}, Changed: map[uint]uint64{1: 2, 9: 1}}) {
+		t.Error("changes of regions not listed were compared")
+	}
+}
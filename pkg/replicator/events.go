@@ -0,0 +1,133 @@
+package replicator
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+)
+
+// pushPendingTimeout bounds pushing pending events to a member that joined.
+const pushPendingTimeout = 10 * time.Second
+
+// MemberEventType tells what happened to a member.
+type MemberEventType int
+
+const (
+	// MemberJoin is sent when a member joins the cluster.
+	MemberJoin MemberEventType = iota
+	// MemberLeave is sent when a member leaves the cluster or dies.
+	MemberLeave
+	// MemberUpdate is sent when a member updates its metadata.
+	MemberUpdate
+)
+
+func (t MemberEventType) String() string {
+	switch t {
+	case MemberJoin:
+		return "join"
+	case MemberLeave:
+		return "leave"
+	case MemberUpdate:
+		return "update"
+	}
+	return "unknown"
+}
+
+// MemberEvent describes a change of the cluster membership.
+type MemberEvent struct {
+	Type MemberEventType
+
+	// Name and Address identify the member in the gossip layer.
+	Name    string
+	Address string
+
+	// State is alive, dead or left, as reported by ListMembers.
+	State string
+
+	// Meta is the metadata the member gossiped last.
+	Meta nodemeta.Meta
+}
+
+// Subscribe sends the membership changes seen by the node to ch, until
+// the returned function is called. Sends never block: events are dropped,
+// with a warning, when ch is full, so give it a buffer to keep up.
+func (n *Node) Subscribe(ch chan<- MemberEvent) (unsubscribe func()) {
+	return n.members.subscribe(ch)
+}
+
+func (m *membership) subscribe(ch chan<- MemberEvent) func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := m.nextID
+	m.nextID++
+	m.subscribers[id] = ch
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.subscribers, id)
+	}
+}
+
+// notify fans ev out to the subscribers; m.mu is held.
+func (m *membership) notify(ev MemberEvent) {
+	for _, ch := range m.subscribers {
+		select {
+		case ch <- ev:
+		default:
+			m.logger.Warn("dropped member event, subscriber is not keeping up",
+				"event", ev.Type, "member", ev.Name)
+		}
+	}
+}
+
+func (m *membership) event(t MemberEventType, node memberlist.Node, state string) MemberEvent {
+	ev := MemberEvent{Type: t, Name: node.Name, Address: node.Address(), State: state}
+	md, err := nodemeta.Decode(node.Meta)
+	if err != nil {
+		m.logger.Warn("failed to decode member metadata", "member", node.Name, "error", err)
+	}
+	ev.Meta = md
+	return ev
+}
+
+// watchTopology re-evaluates the replication state when members join, leave
+// or change: the regions that must commit an event follow the membership,
+// events every region committed are marked for deletion, and
+// pending events are pushed to the members that joined or came back, rather
+// than waiting for the next push/pull.
+//
+// memberlist calls its event delegate while holding its own locks, so the
+// work happens here and changes arriving meanwhile are coalesced.
+func (n *Node) watchTopology() {
+	defer n.loops.Done()
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-n.members.changed:
+		}
+
+		n.refreshRegions()
+		marked, err := n.delegate.ReevaluateCommits()
+		if err != nil {
+			n.logger.Error("failed to re-evaluate commits", "error", err)
+		} else if marked > 0 {
+			n.logger.Debug("re-evaluated commits after topology change", "marked", marked)
+		}
+
+		joined := n.members.takeJoined()
+		if len(joined) == 0 {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), pushPendingTimeout)
+		sent, err := n.delegate.PushPending(ctx, joined...)
+		cancel()
+		if err != nil {
+			n.logger.Warn("failed to push pending events to joined members", "members", joined, "error", err)
+		} else if sent > 0 {
+			n.logger.Info("pushed pending events to joined members", "events", sent, "members", joined)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package replicator_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+func TestSubscribe(t *testing.T) {
+	sb := replicatortest.NewSwitchboard(1)
+	a, _ := startNode(t, sb, "a", 1)
+	events := make(chan replicator.MemberEvent, 64)
+	unsubscribe := a.Subscribe(events)
+
+	b, _ := startNode(t, sb, "b", 2, replicator.WithSeeds(sb.Addr("a")))
+	ctx := context.Background()
+	// gossips new metadata
+	if _, err := b.UpdateRegions(ctx, &rpc.UpdateRegionsRequest{Register: []int32{3}}); err != nil {
+		t.Fatal(err)
+	}
+	// wait for the update before leaving, so that it cannot be coalesced
+	var got []replicator.MemberEventType
+	next := func() replicator.MemberEvent {
+		t.Helper()
+		for {
+			select {
+			case ev := <-events:
+				if ev.Name != "b" {
+					continue
+				}
+				if len(got) == 0 || got[len(got)-1] != ev.Type {
+					got = append(got, ev.Type)
+				}
+				return ev
+			case <-time.After(10 * time.Second):
+				t.Fatalf("received %v, then nothing", got)
+			}
+		}
+	}
+	for ev := next(); ev.Type != replicator.MemberUpdate; ev = next() {
+		if ev.Type == replicator.MemberJoin && (ev.Meta.Region != 2 || ev.State != "alive") {
+			t.Errorf("b joined as %s in region %d, want alive in region 2", ev.State, ev.Meta.Region)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	b.Shutdown(shutdownCtx)
+	for ev := next(); ev.Type != replicator.MemberLeave; ev = next() {
+	}
+	want := []replicator.MemberEventType{replicator.MemberJoin, replicator.MemberUpdate, replicator.MemberLeave}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("received %v, want %v", got, want)
+	}
+
+	// nothing is sent once unsubscribed
+	unsubscribe()
+	startNode(t, sb, "c", 3, replicator.WithSeeds(sb.Addr("a")))
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		members, err := a.ListMembers(ctx, &rpc.ListMembersRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(members.Members) == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("c did not join, %d members", len(members.Members))
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	for {
+		select {
+		case ev := <-events:
+			if ev.Name == "c" {
+				t.Fatalf("received %v of c after unsubscribing", ev.Type)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func TestRegistryChangesReevaluateCommits(t *testing.T) {
+	// region 3 never joins, events wait for it
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 2, NumberOfRegions: 3})
+	ctx := context.Background()
+	c.Put(0, "k", "v")
+	c.WaitConverged("k")
+
+	pending := func(region int32) int64 {
+		s, err := c.Node(0).GetReplicationStatus(ctx, &rpc.GetReplicationStatusRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pendingByRegion(s)[region]
+	}
+	if _, err := c.Node(0).UpdateRegions(ctx, &rpc.UpdateRegionsRequest{Register: []int32{4}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := pending(4); got != 1 {
+		t.Fatalf("%d events pending for the region registered, want 1", got)
+	}
+
+	// once neither waited for, the event is complete and deleted everywhere
+	if _, err := c.Node(1).UpdateRegions(ctx, &rpc.UpdateRegionsRequest{Retire: []int32{3, 4}}); err != nil {
+		t.Fatal(err)
+	}
+	c.WaitFor(func() (bool, string) {
+		for i := 0; i < c.Len(); i++ {
+			_, err := c.Get(i, "k")
+			if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != twirp.NotFound {
+				return false, c.Name(i) + " still holds k"
+			}
+		}
+		return true, ""
+	})
+}
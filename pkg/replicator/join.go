@@ -0,0 +1,234 @@
+package replicator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+const (
+	defaultJoinAttempts     = 5
+	defaultJoinBackoff      = 500 * time.Millisecond
+	defaultJoinMaxBackoff   = 30 * time.Second
+	defaultRejoinInterval   = 30 * time.Second
+	defaultDNSLookupTimeout = 5 * time.Second
+)
+
+// joinConfig controls how a node finds and joins the cluster.
+type joinConfig struct {
+	// addr:port of nodes in the cluster to join to
+	seeds []string
+
+	// DNS name resolving to seeds: an SRV record when it starts with an
+	// underscore, A/AAAA records otherwise
+	seedDNS string
+
+	// host:port of the DNS server answering seedDNS; the system resolver if empty
+	dnsServer string
+
+	// initial join attempts, with an exponential backoff between them
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+
+	// how often a node alone in the cluster tries to join again; disabled if negative
+	rejoinInterval time.Duration
+}
+
+func defaultJoinConfig() joinConfig {
+	return joinConfig{
+		attempts:       defaultJoinAttempts,
+		backoff:        defaultJoinBackoff,
+		maxBackoff:     defaultJoinMaxBackoff,
+		rejoinInterval: defaultRejoinInterval,
+	}
+}
+
+func (n *Node) joinCluster() {
+	ml, err := memberlist.Create(n.memberConfig)
+	if err != nil {
+		n.report(fmt.Errorf("start gossip: %w", err))
+		return
+	}
+	n.mu.Lock()
+	select {
+	case <-n.stop:
+		// shut down while gossip started, nobody else will stop it
+		n.mu.Unlock()
+		ml.Shutdown()
+		return
+	default:
+	}
+	// the local node memberlist returns is updated in place, e.g. on Shutdown
+	n.gossipAddr = ml.LocalNode().Address()
+	n.memberlist = ml
+	n.delegate.SetMemberlist(ml)
+	n.mu.Unlock()
+
+	if len(n.join.seeds) == 0 && n.join.seedDNS == "" {
+		n.logger.Info("no seeds, first node of the cluster")
+		return
+	}
+
+	backoff := n.join.backoff
+	for attempt := 1; ; attempt++ {
+		err = n.tryJoin()
+		if err == nil {
+			break
+		}
+		n.logger.Warn("failed to join cluster", "attempt", attempt, "attempts", n.join.attempts, "error", err)
+		if attempt >= n.join.attempts {
+			if n.join.rejoinInterval < 0 {
+				n.report(fmt.Errorf("join cluster: %w", err))
+				return
+			}
+			n.logger.Warn("running alone, will keep trying to join", "interval", n.join.rejoinInterval)
+			break
+		}
+
+		select {
+		case <-n.stop:
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > n.join.maxBackoff {
+			backoff = n.join.maxBackoff
+		}
+	}
+
+	if n.join.rejoinInterval > 0 {
+		go n.rejoinWhenAlone()
+	}
+}
+
+// rejoinWhenAlone periodically tries the seeds again while no other member is alive,
+// e.g. after the node was partitioned away or started before its seeds.
+func (n *Node) rejoinWhenAlone() {
+	ticker := time.NewTicker(n.join.rejoinInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+			if n.memberlist.NumMembers() > 1 {
+				continue
+			}
+			err := n.tryJoin()
+			if err != nil {
+				n.logger.Warn("node is alone, failed to rejoin cluster", "error", err)
+			}
+		}
+	}
+}
+
+// tryJoin resolves the seeds and joins every one it can reach.
+func (n *Node) tryJoin() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultDNSLookupTimeout)
+	defer cancel()
+	seeds, err := n.resolveSeeds(ctx)
+	if err != nil {
+		return err
+	}
+	if len(seeds) == 0 {
+		return fmt.Errorf("no seed to join besides this node")
+	}
+
+	n.logger.Info("joining cluster", "seeds", strings.Join(seeds, ","))
+	joined, err := n.memberlist.Join(seeds)
+	// a seed naming this node under another address only joins itself
+	if joined == 0 || n.memberlist.NumMembers() <= 1 {
+		if err == nil {
+			err = fmt.Errorf("no seed reachable")
+		}
+		return err
+	}
+	n.logger.Info("joined cluster", "reached", joined, "seeds", len(seeds), "members", n.memberlist.NumMembers())
+	return nil
+}
+
+// resolveSeeds returns the static seeds and the ones found in DNS, without this node.
+func (n *Node) resolveSeeds(ctx context.Context) ([]string, error) {
+	seeds := append([]string{}, n.join.seeds...)
+	if n.join.seedDNS != "" {
+		found, err := lookupSeeds(ctx, n.resolver(), n.join.seedDNS, n.memberConfig.BindPort)
+		if err != nil {
+			// static seeds may still work
+			if len(seeds) == 0 {
+				return nil, err
+			}
+			n.logger.Warn("failed to discover seeds in DNS", "name", n.join.seedDNS, "error", err)
+		}
+		seeds = append(seeds, found...)
+	}
+
+	seen := make(map[string]bool, len(seeds))
+	out := seeds[:0]
+	for _, seed := range seeds {
+		if seed == n.gossipAddr || seen[seed] {
+			continue
+		}
+		seen[seed] = true
+		out = append(out, seed)
+	}
+	return out, nil
+}
+
+// resolver returns the resolver used for seed discovery.
+func (n *Node) resolver() *net.Resolver {
+	if n.join.dnsServer == "" {
+		return net.DefaultResolver
+	}
+	server := n.join.dnsServer
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// lookupSeeds resolves name into host:port seeds. Names starting with an
+// underscore, such as _gossip._tcp.replicator.local, are looked up as SRV
+// records; anything else as A/AAAA records, optionally followed by :port.
+func lookupSeeds(ctx context.Context, resolver *net.Resolver, name string, defaultPort int) ([]string, error) {
+	if strings.HasPrefix(name, "_") {
+		_, records, err := resolver.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		// resolve the targets here too, memberlist would not use the same DNS server
+		var seeds []string
+		for _, srv := range records {
+			addrs, err := resolver.LookupHost(ctx, strings.TrimSuffix(srv.Target, "."))
+			if err != nil {
+				return nil, err
+			}
+			for _, addr := range addrs {
+				seeds = append(seeds, net.JoinHostPort(addr, strconv.Itoa(int(srv.Port))))
+			}
+		}
+		return seeds, nil
+	}
+
+	host, port := name, strconv.Itoa(defaultPort)
+	if h, p, err := net.SplitHostPort(name); err == nil {
+		host, port = h, p
+	}
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var seeds []string
+	for _, addr := range addrs {
+		seeds = append(seeds, net.JoinHostPort(addr, port))
+	}
+	return seeds, nil
+}
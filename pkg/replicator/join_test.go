@@ -0,0 +1,158 @@
+package replicator
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// dnsServer answers the DNS queries of seed discovery from records, over UDP.
+type dnsServer struct {
+	conn net.PacketConn
+
+	srv  map[string][]dnsmessage.SRVResource
+	a    map[string][][4]byte
+	aaaa map[string][][16]byte
+}
+
+func newDNSServer(t *testing.T) *dnsServer {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &dnsServer{
+		conn: conn,
+		srv:  make(map[string][]dnsmessage.SRVResource),
+		a:    make(map[string][][4]byte),
+		aaaa: make(map[string][][16]byte),
+	}
+	t.Cleanup(func() { conn.Close() })
+	return s
+}
+
+func (s *dnsServer) addr() string { return s.conn.LocalAddr().String() }
+
+// serve answers queries until the server is closed. Records must not change meanwhile.
+func (s *dnsServer) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, from, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var p dnsmessage.Parser
+		h, err := p.Start(buf[:n])
+		if err != nil {
+			continue
+		}
+		q, err := p.Question()
+		if err != nil {
+			continue
+		}
+		resp, err := s.answer(h, q)
+		if err != nil {
+			continue
+		}
+		s.conn.WriteTo(resp, from)
+	}
+}
+
+func (s *dnsServer) answer(h dnsmessage.Header, q dnsmessage.Question) ([]byte, error) {
+	name := strings.ToLower(q.Name.String())
+	rh := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 60}
+	var answers []func(b *dnsmessage.Builder) error
+	switch q.Type {
+	case dnsmessage.TypeSRV:
+		for _, r := range s.srv[name] {
+			r := r
+			answers = append(answers, func(b *dnsmessage.Builder) error { return b.SRVResource(rh, r) })
+		}
+	case dnsmessage.TypeA:
+		for _, ip := range s.a[name] {
+			ip := ip
+			answers = append(answers, func(b *dnsmessage.Builder) error { return b.AResource(rh, dnsmessage.AResource{A: ip}) })
+		}
+	case dnsmessage.TypeAAAA:
+		for _, ip := range s.aaaa[name] {
+			ip := ip
+			answers = append(answers, func(b *dnsmessage.Builder) error {
+				return b.AAAAResource(rh, dnsmessage.AAAAResource{AAAA: ip})
+			})
+		}
+	}
+	_, found := s.srv[name]
+	if !found {
+		_, found = s.a[name]
+	}
+	if !found {
+		_, found = s.aaaa[name]
+	}
+
+	rcode := dnsmessage.RCodeSuccess
+	if !found {
+		rcode = dnsmessage.RCodeNameError
+	}
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID: h.ID, Response: true, Authoritative: true, RCode: rcode,
+	})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	for _, add := range answers {
+		if err := add(&b); err != nil {
+			return nil, err
+		}
+	}
+	return b.Finish()
+}
+
+func TestLookupSeeds(t *testing.T) {
+	s := newDNSServer(t)
+	s.srv["_gossip._tcp.replicator.test."] = []dnsmessage.SRVResource{
+		{Target: dnsmessage.MustNewName("node1.replicator.test."), Port: 7946},
+		{Target: dnsmessage.MustNewName("node2.replicator.test."), Port: 7947},
+	}
+	s.a["node1.replicator.test."] = [][4]byte{{10, 0, 0, 1}}
+	s.aaaa["node2.replicator.test."] = [][16]byte{{0xfd, 15: 2}}
+	s.a["seeds.replicator.test."] = [][4]byte{{10, 0, 0, 1}, {10, 0, 0, 2}}
+	go s.serve()
+
+	n := &Node{join: joinConfig{dnsServer: s.addr()}}
+	for _, tt := range []struct {
+		name string
+		want []string
+		err  bool
+	}{
+		{"_gossip._tcp.replicator.test", []string{"10.0.0.1:7946", "[fd00::2]:7947"}, false},
+		{"seeds.replicator.test", []string{"10.0.0.1:7900", "10.0.0.2:7900"}, false},
+		{"seeds.replicator.test:7000", []string{"10.0.0.1:7000", "10.0.0.2:7000"}, false},
+		{"_missing._tcp.replicator.test", nil, true},
+		{"missing.replicator.test", nil, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			seeds, err := lookupSeeds(ctx, n.resolver(), tt.name, 7900)
+			if (err != nil) != tt.err {
+				t.Fatalf("lookupSeeds(%q) = %v, want an error: %t", tt.name, err, tt.err)
+			}
+			sort.Strings(seeds)
+			if !reflect.DeepEqual(seeds, tt.want) {
+				t.Errorf("lookupSeeds(%q) = %v, want %v", tt.name, seeds, tt.want)
+			}
+		})
+	}
+}
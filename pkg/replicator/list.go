@@ -0,0 +1,95 @@
+package replicator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+
+	// defaultListScanLimit bounds the keys a single ListEvents call examines
+	defaultListScanLimit = 10000
+)
+
+// ListEvents scans the local store by key prefix and returns one page of
+// the events matching the request filters. Deleted events are skipped.
+// A call examines at most the scan limit of keys, see WithListScanLimit:
+// when filters leave out most of them, the page holds fewer events than
+// asked for, maybe none, and the next page token carries on the scan.
+func (n *Node) ListEvents(ctx context.Context, req *rpc.ListEventsRequest) (*rpc.ListEventsResponse, error) {
+	pageSize := int(req.PageSize)
+	if pageSize < 0 {
+		return nil, twirp.InvalidArgumentError("page_size", "must not be negative")
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	var start string
+	if req.PageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+		if err != nil {
+			return nil, twirp.InvalidArgumentError("page_token", "is malformed")
+		}
+		start = string(b)
+	}
+
+	resp := &rpc.ListEventsResponse{}
+	var scanned int
+	err := n.storage.Scan(req.Prefix, start, func(key string, value []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if storage.IsLocal(key) {
+			return nil
+		}
+		if len(resp.Events) == pageSize || scanned == n.listScanLimit {
+			// the first key left out is where the next page starts
+			resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(key))
+			return storage.ErrStopIteration
+		}
+		scanned++
+
+		var v storage.V
+		if err := json.Unmarshal(value, &v); err != nil {
+			n.logger.Warn("skipping unreadable event", "key", key, "error", err)
+			return nil
+		}
+		if matches(req, v) {
+			resp.Events = append(resp.Events, toEvent(v))
+		}
+		return nil
+	})
+	if err != nil {
+		n.logger.Error("failed to list events", "prefix", req.Prefix, "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// matches reports whether v passes the filters set on req
+func matches(req *rpc.ListEventsRequest, v storage.V) bool {
+	if v.Deleted() {
+		return false
+	}
+	if req.ServiceCode != "" && req.ServiceCode != v.Meta.SVCCode {
+		return false
+	}
+	if req.SourceRegion != 0 && int(req.SourceRegion) != v.Meta.SourceRegion {
+		return false
+	}
+	if req.ActionName != "" && req.ActionName != v.ActionName {
+		return false
+	}
+	return true
+}
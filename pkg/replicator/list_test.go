@@ -0,0 +1,179 @@
+package replicator_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+// listAll follows the page tokens from req and returns the ids listed and
+// the number of pages.
+func listAll(t *testing.T, n *replicator.Node, req *rpc.ListEventsRequest) (ids []string, pages int) {
+	t.Helper()
+	for {
+		resp, err := n.ListEvents(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		for _, ev := range resp.Events {
+			ids = append(ids, ev.Id)
+		}
+		if resp.NextPageToken == "" {
+			return ids, pages
+		}
+		if pages > 100 {
+			t.Fatalf("still listing after %d pages", pages)
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// putEvents writes key-00 to key-<n-1> on node 0, every tenth one by the
+// billing service.
+func putEvents(t *testing.T, c *replicatortest.Cluster, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		req := &rpc.PutEventRequest{Id: fmt.Sprintf("key-%02d", i), Data: "v", SourceRegion: 1, ServiceCode: "orders"}
+		if i%10 == 0 {
+			req.ServiceCode, req.ActionName, req.SourceRegion = "billing", "charge", 2
+		}
+		if _, err := c.Node(0).Put(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func keys(from, to, step int) []string {
+	var ids []string
+	for i := from; i < to; i += step {
+		ids = append(ids, fmt.Sprintf("key-%02d", i))
+	}
+	return ids
+}
+
+func TestListEventsPages(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 1, KeepEvents: true})
+	putEvents(t, c, 25)
+	n := c.Node(0)
+
+	for _, tt := range []struct {
+		name  string
+		req   *rpc.ListEventsRequest
+		want  []string
+		pages int
+	}{
+		{"one page", &rpc.ListEventsRequest{}, keys(0, 25, 1), 1},
+		{"pages of 10", &rpc.ListEventsRequest{PageSize: 10}, keys(0, 25, 1), 3},
+		{"exact pages", &rpc.ListEventsRequest{PageSize: 5}, keys(0, 25, 1), 5},
+		{"prefix", &rpc.ListEventsRequest{Prefix: "key-1", PageSize: 4}, keys(10, 20, 1), 3},
+		{"no match", &rpc.ListEventsRequest{Prefix: "other"}, nil, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, pages := listAll(t, n, tt.req)
+			if !reflect.DeepEqual(ids, tt.want) || pages != tt.pages {
+				t.Errorf("listed %v in %d pages, want %v in %d", ids, pages, tt.want, tt.pages)
+			}
+		})
+	}
+
+	// a page token resumes where the page stopped
+	resp, err := n.ListEvents(context.Background(), &rpc.ListEventsRequest{PageSize: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = n.ListEvents(context.Background(), &rpc.ListEventsRequest{PageSize: 3, PageToken: resp.NextPageToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 3 || resp.Events[0].Id != "key-03" {
+		t.Errorf("second page %v, want key-03 to key-05", resp.Events)
+	}
+}
+
+func TestListEventsFilters(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 1, KeepEvents: true})
+	putEvents(t, c, 25)
+	c.Delete(0, "key-10")
+	n := c.Node(0)
+
+	billing := []string{"key-00", "key-20"}
+	for _, tt := range []struct {
+		name string
+		req  *rpc.ListEventsRequest
+		want []string
+	}{
+		{"deleted skipped", &rpc.ListEventsRequest{Prefix: "key-1"}, keys(11, 20, 1)},
+		{"service code", &rpc.ListEventsRequest{ServiceCode: "billing"}, billing},
+		{"source region", &rpc.ListEventsRequest{SourceRegion: 2}, billing},
+		{"action name", &rpc.ListEventsRequest{ActionName: "charge"}, billing},
+		{"every filter", &rpc.ListEventsRequest{ServiceCode: "billing", SourceRegion: 1}, nil},
+		{"filters across pages", &rpc.ListEventsRequest{ServiceCode: "billing", PageSize: 1}, billing},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, _ := listAll(t, n, tt.req)
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("listed %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEventsScanLimit(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{
+		Nodes:      1,
+		KeepEvents: true,
+		Options:    []replicator.Option{replicator.WithListScanLimit(4)},
+	})
+	putEvents(t, c, 25)
+	n := c.Node(0)
+
+	// the first call stops after key-03 without finding key-10
+	resp, err := n.ListEvents(context.Background(), &rpc.ListEventsRequest{ServiceCode: "billing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].Id != "key-00" || resp.NextPageToken == "" {
+		t.Fatalf("first page %v with token %q, want key-00 and a token", resp.Events, resp.NextPageToken)
+	}
+	resp, err = n.ListEvents(context.Background(), &rpc.ListEventsRequest{ServiceCode: "billing", PageToken: resp.NextPageToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 0 || resp.NextPageToken == "" {
+		t.Fatalf("second page %v with token %q, want no events and a token", resp.Events, resp.NextPageToken)
+	}
+
+	// following the tokens still lists everything, 4 keys a call
+	ids, pages := listAll(t, n, &rpc.ListEventsRequest{ServiceCode: "billing"})
+	if want := keys(0, 25, 10); !reflect.DeepEqual(ids, want) || pages != 7 {
+		t.Errorf("listed %v in %d pages, want %v in 7", ids, pages, want)
+	}
+	ids, pages = listAll(t, n, &rpc.ListEventsRequest{PageSize: 10})
+	if want := keys(0, 25, 1); !reflect.DeepEqual(ids, want) || pages != 7 {
+		t.Errorf("listed %v in %d pages, want %v in 7", ids, pages, want)
+	}
+}
+
+func TestListEventsInvalidArguments(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 1})
+	for _, tt := range []struct {
+		req      *rpc.ListEventsRequest
+		argument string
+	}{
+		{&rpc.ListEventsRequest{PageSize: -1}, "page_size"},
+		{&rpc.ListEventsRequest{PageToken: "not base64!"}, "page_token"},
+	} {
+		_, err := c.Node(0).ListEvents(context.Background(), tt.req)
+		twerr, ok := err.(twirp.Error)
+		if !ok || twerr.Code() != twirp.InvalidArgument || twerr.Meta("argument") != tt.argument {
+			t.Errorf("ListEvents(%v) = %v, want an invalid %s", tt.req, err, tt.argument)
+		}
+	}
+}
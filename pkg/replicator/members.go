@@ -0,0 +1,221 @@
+package replicator
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+)
+
+// departedRetention is how long dead and left members are still listed.
+const departedRetention = time.Hour
+
+// Member states reported by ListMembers.
+const (
+	stateAlive = "alive"
+	stateDead  = "dead"
+	stateLeft  = "left"
+)
+
+// metaState is set in the node metadata to stateLeaving by a node shutting
+// down, so that peers report it as left rather than dead. It is best-effort:
+// a peer the update has not reached when the node goes reports it dead.
+const (
+	metaState    = "state"
+	stateLeaving = "leaving"
+)
+
+// membership remembers the members memberlist told the node about, so that
+// the ones that died or left can still be listed for a while.
+//
+// memberlist does not expose the state of its members: the ones it returns
+// are alive, or suspected without being declared dead yet, and the others
+// are reported through NotifyLeave whether they failed or left.
+//
+// It also tells subscribers about the changes, and signals changed so that
+// the node re-evaluates what depends on the topology; see watchTopology.
+type membership struct {
+	mu      sync.Mutex
+	members map[string]member
+	logger  logging.Logger
+
+	// told where to send replication messages
+	delegate *storage.Delegate
+
+	subscribers map[int]chan<- MemberEvent
+	nextID      int
+
+	// joined are the members that joined or updated since watchTopology
+	// last ran, excluding the local node.
+	joined  map[string]bool
+	local   string
+	changed chan struct{}
+}
+
+type member struct {
+	node  memberlist.Node
+	state string
+	seen  time.Time
+}
+
+var _ memberlist.EventDelegate = (*membership)(nil)
+
+func newMembership(local string, delegate *storage.Delegate, logger logging.Logger) *membership {
+	return &membership{
+		members:     make(map[string]member),
+		logger:      logger,
+		delegate:    delegate,
+		subscribers: make(map[int]chan<- MemberEvent),
+		joined:      make(map[string]bool),
+		local:       local,
+		changed:     make(chan struct{}, 1),
+	}
+}
+
+func (m *membership) update(t MemberEventType, n *memberlist.Node, state string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node := *n
+	node.Meta = append([]byte{}, n.Meta...)
+	if state != stateAlive {
+		// the last metadata received tells whether it meant to leave
+		if md, err := nodemeta.Decode(m.members[n.Name].node.Meta); err == nil && md.Extra[metaState] == stateLeaving {
+			state = stateLeft
+		}
+	}
+	m.members[n.Name] = member{node: node, state: state, seen: time.Now()}
+	if state == stateAlive {
+		m.delegate.PeerAlive(n)
+	} else {
+		m.delegate.PeerGone(n.Name)
+	}
+
+	if state == stateAlive && n.Name != m.local {
+		m.joined[n.Name] = true
+	} else {
+		delete(m.joined, n.Name)
+	}
+	m.notify(m.event(t, node, state))
+	m.touch()
+}
+
+// touch signals watchTopology that the topology changed.
+func (m *membership) touch() {
+	select {
+	case m.changed <- struct{}{}:
+	default:
+	}
+}
+
+// takeJoined returns and forgets the members that joined since last called.
+func (m *membership) takeJoined() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var names []string
+	for name := range m.joined {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	m.joined = make(map[string]bool)
+	return names
+}
+
+// NotifyJoin is invoked when a node is detected to have joined.
+func (m *membership) NotifyJoin(n *memberlist.Node) { m.update(MemberJoin, n, stateAlive) }
+
+// NotifyLeave is invoked when a node is detected to have left or died.
+func (m *membership) NotifyLeave(n *memberlist.Node) { m.update(MemberLeave, n, stateDead) }
+
+// NotifyUpdate is invoked when a node updated its metadata.
+func (m *membership) NotifyUpdate(n *memberlist.Node) { m.update(MemberUpdate, n, stateAlive) }
+
+// alive returns a copy of the members last seen alive, including the
+// local node. Unlike the nodes memberlist returns, they are safe to read
+// while memberlist updates its own.
+func (m *membership) alive() []memberlist.Node {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var nodes []memberlist.Node
+	for _, mb := range m.members {
+		if mb.state == stateAlive {
+			nodes = append(nodes, mb.node)
+		}
+	}
+	return nodes
+}
+
+// departed returns the members last seen dead or left, dropping the ones
+// gone for longer than departedRetention.
+func (m *membership) departed(now time.Time) []member {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var members []member
+	for name, mb := range m.members {
+		if mb.state == stateAlive {
+			continue
+		}
+		if now.Sub(mb.seen) > departedRetention {
+			delete(m.members, name)
+			continue
+		}
+		members = append(members, mb)
+	}
+	return members
+}
+
+// ListMembers returns every member of the cluster known to this node with
+// its gossip state, region and API endpoint, sorted by name. Members that
+// died or left are listed for an hour. Suspected members are reported alive
+// until they are declared dead, memberlist does not expose suspicion.
+func (n *Node) ListMembers(ctx context.Context, req *rpc.ListMembersRequest) (*rpc.ListMembersResponse, error) {
+	n.mu.Lock()
+	ml := n.memberlist
+	n.mu.Unlock()
+
+	resp := &rpc.ListMembersResponse{}
+	if ml == nil {
+		return resp, nil
+	}
+
+	local := ml.LocalNode().Name
+	for _, node := range n.members.alive() {
+		resp.Members = append(resp.Members, n.toMember(node, stateAlive, node.Name == local))
+	}
+	for _, mb := range n.members.departed(time.Now()) {
+		resp.Members = append(resp.Members, n.toMember(mb.node, mb.state, false))
+	}
+	sort.Slice(resp.Members, func(i, j int) bool {
+		return resp.Members[i].Name < resp.Members[j].Name
+	})
+	return resp, nil
+}
+
+// toMember converts a memberlist node to its API representation.
+func (n *Node) toMember(node memberlist.Node, state string, local bool) *rpc.Member {
+	m := &rpc.Member{
+		Name:    node.Name,
+		Address: node.Address(),
+		State:   state,
+		Local:   local,
+	}
+	md, err := nodemeta.Decode(node.Meta)
+	if err != nil {
+		n.logger.Warn("failed to decode member metadata", "member", node.Name, "error", err)
+		return m
+	}
+	m.Region = int32(md.Region)
+	m.ApiEndpoint = md.APIEndpoint(node.Addr.String())
+	m.Role = md.Role
+	m.BuildVersion = md.BuildVersion
+	m.ProtocolVersion = int32(md.Protocol)
+	m.Metadata = md.Extra
+	return m
+}
@@ -0,0 +1,235 @@
+package replicator
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/twitchtv/twirp"
+)
+
+const metricsNamespace = "replicator"
+
+// metrics exports the activity and replication health of a node. Every node
+// has its own registry, so several nodes can run in the same process.
+type metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	mergeDuration *prometheus.HistogramVec
+	keysReceived  *prometheus.CounterVec
+	keysApplied   *prometheus.CounterVec
+	keysSkipped   *prometheus.CounterVec
+
+	tombstonesCollected prometheus.Counter
+	messagesDropped     prometheus.Counter
+
+	// gauges read from the node
+	node *nodeCollector
+}
+
+var _ storage.Observer = (*metrics)(nil)
+
+func newMetrics(n *Node) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		node:     &nodeCollector{node: n},
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "API requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Time spent handling API requests, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		mergeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "merge_duration_seconds",
+			Help:      "Time spent merging a batch of remote entries, by source (pushpull or broadcast).",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"source"}),
+		keysReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sync_keys_received_total",
+			Help:      "Remote entries received, by source.",
+		}, []string{"source"}),
+		keysApplied: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sync_keys_applied_total",
+			Help:      "Remote entries that changed the local state, by source.",
+		}, []string{"source"}),
+		keysSkipped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sync_keys_skipped_total",
+			Help:      "Remote entries already covered by the local state or unreadable, by source.",
+		}, []string{"source"}),
+		tombstonesCollected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "tombstones_collected_total",
+			Help:      "Tombstones garbage collected after every region committed them.",
+		}),
+		messagesDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sync_messages_dropped_total",
+			Help:      "Messages from peers dropped because replies were queued up.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.mergeDuration,
+		m.keysReceived,
+		m.keysApplied,
+		m.keysSkipped,
+		m.tombstonesCollected,
+		m.messagesDropped,
+		m.node,
+	)
+	return m
+}
+
+// Merged implements storage.Observer.
+func (m *metrics) Merged(source string, received, applied int, elapsed time.Duration) {
+	m.mergeDuration.WithLabelValues(source).Observe(elapsed.Seconds())
+	m.keysReceived.WithLabelValues(source).Add(float64(received))
+	m.keysApplied.WithLabelValues(source).Add(float64(applied))
+	m.keysSkipped.WithLabelValues(source).Add(float64(received - applied))
+}
+
+// TombstonesCollected implements storage.Observer.
+func (m *metrics) TombstonesCollected(count int) {
+	m.tombstonesCollected.Add(float64(count))
+}
+
+// MessageDropped implements storage.Observer.
+func (m *metrics) MessageDropped() {
+	m.messagesDropped.Inc()
+}
+
+type requestStartKey struct{}
+
+// serverHooks count the API requests and time them.
+func (m *metrics) serverHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, requestStartKey{}, time.Now()), nil
+		},
+		ResponseSent: func(ctx context.Context) {
+			method, _ := twirp.MethodName(ctx)
+			code, _ := twirp.StatusCode(ctx)
+			m.requests.WithLabelValues(method, code).Inc()
+			if start, ok := ctx.Value(requestStartKey{}).(time.Time); ok {
+				m.requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+			}
+		},
+	}
+}
+
+// nodeCollector reads the state of the node at scrape time. Counting
+// events walks the whole storage, so their gauges come from the last
+// refreshStats instead.
+type nodeCollector struct {
+	node *Node
+
+	mu       sync.Mutex
+	stats    storage.Stats
+	statsErr error
+	// zero until the stats were read once
+	statsAt time.Time
+}
+
+// refreshStats counts the local events for the next scrapes.
+func (c *nodeCollector) refreshStats() {
+	stats, err := c.node.delegate.Stats()
+	if err != nil {
+		c.node.logger.Error("failed to read stats for metrics", "error", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats, c.statsErr, c.statsAt = stats, err, time.Now()
+}
+
+var (
+	pendingDesc = prometheus.NewDesc(metricsNamespace+"_pending_events",
+		"Events not committed yet by a region.", []string{"region"}, nil)
+	eventsDesc = prometheus.NewDesc(metricsNamespace+"_events",
+		"Events stored locally, tombstones included.", nil, nil)
+	tombstonesDesc = prometheus.NewDesc(metricsNamespace+"_tombstones",
+		"Deleted events kept until every region committed them.", nil, nil)
+	badPayloadsDesc = prometheus.NewDesc(metricsNamespace+"_bad_payloads_total",
+		"Gossip payloads from peers that were quarantined.", nil, nil)
+	clockSkewsDesc = prometheus.NewDesc(metricsNamespace+"_clock_skews_total",
+		"Events from peers stamped beyond the maximum clock offset, merged once the local clock caught up.", nil, nil)
+	healthScoreDesc = prometheus.NewDesc(metricsNamespace+"_memberlist_health_score",
+		"Memberlist awareness health score, 0 is healthy and higher is worse.", nil, nil)
+	membersDesc = prometheus.NewDesc(metricsNamespace+"_members",
+		"Alive members of the cluster known to this node, itself included.", nil, nil)
+)
+
+func (c *nodeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pendingDesc
+	ch <- eventsDesc
+	ch <- tombstonesDesc
+	ch <- badPayloadsDesc
+	ch <- clockSkewsDesc
+	ch <- healthScoreDesc
+	ch <- membersDesc
+}
+
+func (c *nodeCollector) Collect(ch chan<- prometheus.Metric) {
+	n := c.node
+	c.mu.Lock()
+	stats, err, at := c.stats, c.statsErr, c.statsAt
+	c.mu.Unlock()
+	switch {
+	case at.IsZero():
+		// not counted yet
+	case err != nil:
+		ch <- prometheus.NewInvalidMetric(eventsDesc, err)
+	default:
+		for region, pending := range stats.Pending {
+			ch <- prometheus.MustNewConstMetric(pendingDesc, prometheus.GaugeValue,
+				float64(pending), strconv.FormatUint(uint64(region), 10))
+		}
+		ch <- prometheus.MustNewConstMetric(eventsDesc, prometheus.GaugeValue, float64(stats.Events))
+		ch <- prometheus.MustNewConstMetric(tombstonesDesc, prometheus.GaugeValue, float64(stats.Tombstones))
+	}
+	ch <- prometheus.MustNewConstMetric(badPayloadsDesc, prometheus.CounterValue, float64(n.delegate.BadPayloads()))
+	ch <- prometheus.MustNewConstMetric(clockSkewsDesc, prometheus.CounterValue, float64(n.delegate.ClockSkews()))
+
+	n.mu.Lock()
+	ml := n.memberlist
+	n.mu.Unlock()
+	if ml != nil {
+		ch <- prometheus.MustNewConstMetric(healthScoreDesc, prometheus.GaugeValue, float64(ml.GetHealthScore()))
+		ch <- prometheus.MustNewConstMetric(membersDesc, prometheus.GaugeValue, float64(ml.NumMembers()))
+	}
+}
+
+// refreshStats periodically counts the local events exported as gauges.
+func (n *Node) refreshStats() {
+	defer n.loops.Done()
+	n.metrics.node.refreshStats()
+	ticker := time.NewTicker(n.statsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+			n.metrics.node.refreshStats()
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package replicator_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+)
+
+// scrape returns the value of an unlabelled metric exported by node i, or
+// "" if it is missing.
+func scrape(t *testing.T, c *replicatortest.Cluster, i int, name string) string {
+	t.Helper()
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", c.Node(i).APIPort()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.HasPrefix(line, name+" ") {
+			return strings.TrimPrefix(line, name+" ")
+		}
+	}
+	return ""
+}
+
+func TestEventGaugesAreCountedOnInterval(t *testing.T) {
+	for _, tt := range []struct {
+		interval time.Duration
+		want     string
+	}{
+		// scrapes read the last count rather than walk the storage
+		{time.Hour, "0"},
+		{50 * time.Millisecond, "1"},
+	} {
+		t.Run(tt.interval.String(), func(t *testing.T) {
+			c := replicatortest.New(t, replicatortest.Config{
+				Nodes:      1,
+				KeepEvents: true,
+				Options:    []replicator.Option{replicator.WithStatsInterval(tt.interval)},
+			})
+			c.WaitFor(func() (bool, string) {
+				return scrape(t, c, 0, "replicator_events") == "0", "events not counted on start"
+			})
+			c.Put(0, "k", "v")
+			time.Sleep(200 * time.Millisecond)
+			if got := scrape(t, c, 0, "replicator_events"); got != tt.want {
+				t.Errorf("replicator_events %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
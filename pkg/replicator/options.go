@@ -0,0 +1,177 @@
+package replicator
+
+import (
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+)
+
+// Option customizes a Node created by NewNode.
+type Option func(*Node)
+
+// WithBackend replaces the default in-memory badger storage with the given backend.
+// The node takes ownership of the backend and closes it on Shutdown.
+func WithBackend(backend storage.Backend) Option {
+	return func(n *Node) {
+		n.storage = backend
+	}
+}
+
+// WithRegions registers regions that must commit every event, even while
+// none of their nodes is up, until the registry is updated through
+// UpdateRegions. The region of the node is always registered; other
+// regions are learned from the membership.
+func WithRegions(regions ...uint) Option {
+	return func(n *Node) {
+		n.registered = append(n.registered, regions...)
+	}
+}
+
+// WithTombstoneGracePeriod sets how long a tombstone acknowledged by every
+// region is kept before being garbage collected. Defaults to one hour.
+func WithTombstoneGracePeriod(d time.Duration) Option {
+	return func(n *Node) {
+		if d > 0 {
+			n.tombstoneGracePeriod = d
+		}
+	}
+}
+
+// WithMaxClockOffset sets how far ahead of the local clock the timestamp of
+// an event from a peer may be; events further ahead are merged once the
+// local clock catches up. 0 accepts any timestamp. Defaults to 500ms.
+func WithMaxClockOffset(d time.Duration) Option {
+	return func(n *Node) {
+		if d >= 0 {
+			n.maxClockOffset = d
+		}
+	}
+}
+
+// WithStatsInterval sets how often the events, tombstones and pending
+// events exported as metrics are counted, which walks the whole storage.
+// Defaults to 15s.
+func WithStatsInterval(d time.Duration) Option {
+	return func(n *Node) {
+		if d > 0 {
+			n.statsInterval = d
+		}
+	}
+}
+
+// WithListScanLimit sets how many keys a ListEvents call examines at most,
+// whether or not they pass the filters, so that a selective filter cannot
+// walk the whole storage in one call. Defaults to 10000.
+func WithListScanLimit(keys int) Option {
+	return func(n *Node) {
+		if keys > 0 {
+			n.listScanLimit = keys
+		}
+	}
+}
+
+// WithSeeds adds host:port gossip addresses of cluster members to join on Start.
+func WithSeeds(seeds ...string) Option {
+	return func(n *Node) {
+		n.join.seeds = append(n.join.seeds, seeds...)
+	}
+}
+
+// WithSeedDNS discovers seeds by resolving name, either an SRV record such
+// as _gossip._tcp.replicator.local or an A/AAAA record optionally followed by
+// :port. Queries go to the DNS server at host:port, or to the system
+// resolver when server is empty.
+func WithSeedDNS(name, server string) Option {
+	return func(n *Node) {
+		n.join.seedDNS = name
+		n.join.dnsServer = server
+	}
+}
+
+// WithJoinRetry sets how many times the node tries to join the seeds on
+// Start, waiting backoff after the first failure and doubling the wait up to
+// maxBackoff. Defaults to 5 attempts from 500ms up to 30s.
+func WithJoinRetry(attempts int, backoff, maxBackoff time.Duration) Option {
+	return func(n *Node) {
+		if attempts > 0 {
+			n.join.attempts = attempts
+		}
+		if backoff > 0 {
+			n.join.backoff = backoff
+		}
+		if maxBackoff > 0 {
+			n.join.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithRejoinInterval sets how often a node left alone tries to join the seeds
+// again. Defaults to 30s; a negative interval disables rejoining and makes a
+// failed initial join fatal.
+func WithRejoinInterval(d time.Duration) Option {
+	return func(n *Node) {
+		if d != 0 {
+			n.join.rejoinInterval = d
+		}
+	}
+}
+
+// WithAdvertiseAddr sets the gossip address given to peers when it differs
+// from the bind address, e.g. behind NAT. A zero port keeps the bind port.
+func WithAdvertiseAddr(addr string, port int) Option {
+	return func(n *Node) {
+		n.memberConfig.AdvertiseAddr = addr
+		if port != 0 {
+			n.memberConfig.AdvertisePort = port
+		}
+	}
+}
+
+// WithLogger sets the logger of the node, its storage and memberlist; every
+// message carries the node name and region. A *slog.Logger can be passed as
+// is. Defaults to info and above written to stderr.
+func WithLogger(l logging.Logger) Option {
+	return func(n *Node) {
+		if l != nil {
+			n.logger = l
+		}
+	}
+}
+
+// WithBuildVersion sets the software version advertised to peers. Defaults
+// to the module version of the binary.
+func WithBuildVersion(version string) Option {
+	return func(n *Node) {
+		n.meta.BuildVersion = version
+	}
+}
+
+// WithRole sets the role advertised to peers, nodemeta.RoleReplica by default.
+func WithRole(role string) Option {
+	return func(n *Node) {
+		if role != "" {
+			n.meta.Role = role
+		}
+	}
+}
+
+// WithMemberlistConfig tunes the memberlist configuration of the node, e.g.
+// to gossip faster or through another Transport in tests. fn runs after the
+// defaults are set; the delegates, name and logger of the node are set
+// afterwards and must not be changed.
+func WithMemberlistConfig(fn func(c *memberlist.Config)) Option {
+	return func(n *Node) {
+		fn(n.memberConfig)
+	}
+}
+
+// WithTransport gossips through t instead of UDP and TCP sockets on the
+// gossip port, e.g. an in-memory network in tests. The node shuts t down
+// on Shutdown.
+func WithTransport(t memberlist.Transport) Option {
+	return func(n *Node) {
+		n.memberConfig.Transport = t
+	}
+}
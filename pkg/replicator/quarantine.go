@@ -0,0 +1,26 @@
+package replicator
+
+import (
+	"context"
+
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+)
+
+// ListQuarantined returns the bad gossip payloads this node received and
+// kept for inspection, see storage.Delegate.Quarantined.
+func (n *Node) ListQuarantined(ctx context.Context, req *rpc.ListQuarantinedRequest) (*rpc.ListQuarantinedResponse, error) {
+	resp := &rpc.ListQuarantinedResponse{Total: n.delegate.BadPayloads()}
+	for _, p := range n.delegate.Quarantined() {
+		bad := &rpc.BadPayload{
+			Kind:       p.Kind,
+			Key:        p.Key,
+			Payload:    p.Payload,
+			ReceivedAt: p.At.UnixNano(),
+		}
+		if p.Err != nil {
+			bad.Error = p.Err.Error()
+		}
+		resp.Payloads = append(resp.Payloads, bad)
+	}
+	return resp, nil
+}
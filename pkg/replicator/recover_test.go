@@ -0,0 +1,121 @@
+package replicator_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/hlc"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+)
+
+func TestStartRecoversBeforeServing(t *testing.T) {
+	dir := t.TempDir()
+	db, err := storage.NewBadgerDB(storage.Options{Dir: dir, GCInterval: -1, Logger: logging.Nop()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// written by a previous run whose clock was slightly ahead
+	stored := hlc.Timestamp{WallTime: time.Now().Add(400 * time.Millisecond).UnixNano(), Region: 2}
+	b, err := json.Marshal(storage.V{ID: "stored", Data: []byte("v"), Meta: storage.Meta{
+		HLC:             stored,
+		CommitedRegions: storage.CommitSet{}.Add(2),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put("stored", b); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err = storage.NewBadgerDB(storage.Options{Dir: dir, GCInterval: -1, Logger: logging.Nop()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, _ := startNode(t, replicatortest.NewSwitchboard(1), "a", 1,
+		replicator.WithBackend(db), replicator.WithRegions(1, 2))
+
+	// the first write after Start is stamped after every stored one
+	ev, err := n.Put(context.Background(), &rpc.PutEventRequest{Id: "new", Data: "v", SourceRegion: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := hlc.Timestamp{WallTime: ev.Meta.Timestamp.WallTime, Logical: ev.Meta.Timestamp.Logical, Region: uint(ev.Meta.Timestamp.Region)}
+	if got.Compare(stored) <= 0 {
+		t.Errorf("write stamped %v, not after the stored %v", got, stored)
+	}
+
+	// and the stored event carries the commit of the region
+	ev, err = n.Get(context.Background(), &rpc.GetEventRequest{Id: "stored"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed := make(map[int32]bool)
+	for _, p := range ev.Meta.CommitedRegions.Pairs {
+		committed[p.Key] = p.Value
+	}
+	if !committed[1] || !committed[2] {
+		t.Errorf("stored event committed by %v, want regions 1 and 2", committed)
+	}
+}
+
+func TestStartLoadsRegions(t *testing.T) {
+	dir := t.TempDir()
+	db, err := storage.NewBadgerDB(storage.Options{Dir: dir, GCInterval: -1, Logger: logging.Nop()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb := replicatortest.NewSwitchboard(1)
+	b, _ := startNode(t, sb, "b", 2)
+	a, _ := startNode(t, sb, "a", 1, replicator.WithBackend(db), replicator.WithSeeds(sb.Addr("b")))
+
+	ctx := context.Background()
+	if _, err := a.UpdateRegions(ctx, &rpc.UpdateRegionsRequest{Register: []int32{5}, Retire: []int32{3}}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		reg, err := a.GetRegions(ctx, &rpc.GetRegionsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reflect.DeepEqual(reg.Regions, []int32{1, 2, 5}) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("regions %v, want region 2 merged from b", reg.Regions)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	// shutting down closes the storage
+	shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := a.Shutdown(shutdownCtx); err != nil {
+		t.Fatal(err)
+	}
+	b.Shutdown(shutdownCtx)
+
+	// restarted alone, it still follows the update and the registry of b
+	db, err = storage.NewBadgerDB(storage.Options{Dir: dir, GCInterval: -1, Logger: logging.Nop()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ = startNode(t, replicatortest.NewSwitchboard(1), "a", 1, replicator.WithBackend(db))
+	reg, err := a.GetRegions(ctx, &rpc.GetRegionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &rpc.RegionRegistry{Version: 1, Registered: []int32{1, 2, 5}, Retired: []int32{3}, Regions: []int32{1, 2, 5}}
+	if !reflect.DeepEqual(reg, want) {
+		t.Errorf("restarted with %+v, want %+v", reg, want)
+	}
+}
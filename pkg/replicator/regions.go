@@ -0,0 +1,311 @@
+package replicator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+// announceRegistryTimeout bounds gossiping a new region registry.
+const announceRegistryTimeout = 2 * time.Second
+
+// regionsKey is the name the region registry is stored under, see
+// storage.Delegate.PutLocal.
+const regionsKey = "regions"
+
+// regionRegistry derives the regions that must commit an event from the
+// registry the operator maintains and from the gossiped membership:
+//
+//   - registered regions always count, even while none of their nodes is up,
+//     so that an outage never lets events be deleted before they commit;
+//   - the region of every alive member counts, and keeps counting after its
+//     nodes are gone unless it is retired, so that new regions need no
+//     reconfiguration;
+//   - retired regions stop counting once their last node is gone.
+//
+// The registry is gossiped in the node metadata and the registries of the
+// peers are merged, see nodemeta.Registry.Merge. Both the registry and the
+// learned regions are stored, so that a restarted node neither forgets a
+// change nor drops a region whose nodes are down.
+type regionRegistry struct {
+	mu       sync.Mutex
+	registry nodemeta.Registry
+	learned  map[uint]bool
+	// whether the registry or the learned regions changed since saved
+	dirty bool
+}
+
+// savedRegions is the stored form of a regionRegistry.
+type savedRegions struct {
+	Registry nodemeta.Registry `json:"registry"`
+	Learned  []uint            `json:"learned"`
+}
+
+// newRegionRegistry starts from the regions the node was configured with,
+// the version 0 registry.
+func newRegionRegistry(registered []uint) *regionRegistry {
+	set := make(map[uint]bool, len(registered))
+	for _, region := range registered {
+		if region != 0 {
+			set[region] = true
+		}
+	}
+	return &regionRegistry{
+		registry: nodemeta.Registry{Regions: sortedRegions(set)},
+		learned:  make(map[uint]bool),
+	}
+}
+
+func (r *regionRegistry) get() nodemeta.Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.registry
+}
+
+// adopt merges reg into the registry and reports whether it changed.
+func (r *regionRegistry) adopt(reg nodemeta.Registry) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	merged := r.registry.Merge(reg)
+	if merged.Equal(r.registry) {
+		return false
+	}
+	r.registry = merged
+	for _, region := range merged.Retired {
+		delete(r.learned, region)
+	}
+	r.dirty = true
+	return true
+}
+
+// restore adopts the registry and learns the regions of a previous run.
+func (r *regionRegistry) restore(saved savedRegions) {
+	r.adopt(saved.Registry)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	retired := make(map[uint]bool, len(r.registry.Retired))
+	for _, region := range r.registry.Retired {
+		retired[region] = true
+	}
+	for _, region := range saved.Learned {
+		if region != 0 && !retired[region] {
+			r.learned[region] = true
+		}
+	}
+}
+
+// unsaved returns the stored form of the registry if it changed since the
+// last call.
+func (r *regionRegistry) unsaved() (savedRegions, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.dirty {
+		return savedRegions{}, false
+	}
+	r.dirty = false
+	return savedRegions{Registry: r.registry, Learned: sortedRegions(r.learned)}, true
+}
+
+// changed marks the registry as not saved, as after a failed save.
+func (r *regionRegistry) changed() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirty = true
+}
+
+// resolve returns the regions that must commit an event, given the
+// regions of the alive members.
+func (r *regionRegistry) resolve(alive map[uint]bool) []uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	retired := make(map[uint]bool, len(r.registry.Retired))
+	for _, region := range r.registry.Retired {
+		retired[region] = true
+	}
+	set := make(map[uint]bool)
+	for _, region := range r.registry.Regions {
+		set[region] = true
+	}
+	for region := range alive {
+		if !set[region] && !retired[region] && !r.learned[region] {
+			r.learned[region] = true
+			r.dirty = true
+		}
+		set[region] = true
+	}
+	for region := range r.learned {
+		set[region] = true
+	}
+	return sortedRegions(set)
+}
+
+// refreshRegions merges the registries gossiped by the alive members and
+// updates the regions that must commit an event. The metadata comes
+// from the copies membership keeps: the nodes memberlist returns are
+// updated in place while gossip runs.
+func (n *Node) refreshRegions() {
+	alive := make(map[uint]bool)
+	var changed bool
+	for _, node := range n.members.alive() {
+		md, err := nodemeta.Decode(node.Meta)
+		if err != nil || md.Region == 0 {
+			continue
+		}
+		alive[md.Region] = true
+		if n.regions.adopt(md.Registry) {
+			changed = true
+		}
+	}
+	if changed {
+		reg := n.regions.get()
+		n.logger.Info("merged region registry", "version", reg.Version,
+			"registered", reg.Regions, "retired", reg.Retired)
+		n.announceRegistry(reg)
+	}
+
+	regions := n.regions.resolve(alive)
+	n.saveRegions()
+	if n.delegate.SetRegions(regions) {
+		n.logger.Info("regions that must commit events changed", "regions", n.delegate.Regions())
+	}
+}
+
+// saveRegions stores the registry and the learned regions if they changed.
+// A failed save is retried on the next change of the membership.
+func (n *Node) saveRegions() {
+	saved, ok := n.regions.unsaved()
+	if !ok {
+		return
+	}
+	b, err := json.Marshal(saved)
+	if err == nil {
+		err = n.delegate.PutLocal(regionsKey, b)
+	}
+	if err != nil {
+		n.regions.changed()
+		n.logger.Warn("failed to store region registry", "version", saved.Registry.Version, "error", err)
+	}
+}
+
+// loadRegions restores the registry and the learned regions stored by a
+// previous run, before the node gossips or counts commits.
+func (n *Node) loadRegions() error {
+	b, err := n.delegate.GetLocal(regionsKey)
+	if errors.Is(err, storage.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var saved savedRegions
+	if err := json.Unmarshal(b, &saved); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	n.regions.restore(saved)
+	reg := n.regions.get()
+	n.delegate.SetRegistry(reg)
+	n.delegate.SetRegions(n.regions.resolve(nil))
+	n.saveRegions()
+	n.logger.Info("loaded region registry", "version", reg.Version,
+		"registered", reg.Regions, "retired", reg.Retired, "regions", n.delegate.Regions())
+	return nil
+}
+
+// announceRegistry gossips reg in the metadata of the node, so that it
+// spreads even if the node that published it leaves.
+func (n *Node) announceRegistry(reg nodemeta.Registry) {
+	n.delegate.SetRegistry(reg)
+	n.mu.Lock()
+	ml := n.memberlist
+	n.mu.Unlock()
+	if ml == nil {
+		return
+	}
+	err := ml.UpdateNode(announceRegistryTimeout)
+	if err != nil {
+		n.logger.Warn("failed to announce region registry", "version", reg.Version, "error", err)
+	}
+}
+
+// GetRegions returns the region registry the node follows and the regions
+// that must commit an event.
+func (n *Node) GetRegions(ctx context.Context, req *rpc.GetRegionsRequest) (*rpc.RegionRegistry, error) {
+	reg := n.regions.get()
+	return &rpc.RegionRegistry{
+		Version:    reg.Version,
+		Registered: toInt32s(reg.Regions),
+		Retired:    toInt32s(reg.Retired),
+		Regions:    toInt32s(n.delegate.Regions()),
+	}, nil
+}
+
+// UpdateRegions registers and retires regions, then gossips the new
+// registry to the cluster. Retiring a region takes effect once its last
+// node is gone; the region of the node answering cannot be retired.
+func (n *Node) UpdateRegions(ctx context.Context, req *rpc.UpdateRegionsRequest) (*rpc.RegionRegistry, error) {
+	if len(req.Register) == 0 && len(req.Retire) == 0 {
+		return nil, twirp.InvalidArgumentError("register", "or retire must list a region")
+	}
+	for _, region := range req.Register {
+		if region <= 0 {
+			return nil, twirp.InvalidArgumentError("register", "regions must be positive")
+		}
+	}
+	for _, region := range req.Retire {
+		if region <= 0 {
+			return nil, twirp.InvalidArgumentError("retire", "regions must be positive")
+		}
+		if uint(region) == n.regionID {
+			return nil, twirp.InvalidArgumentError("retire", "must not list the region of this node")
+		}
+	}
+
+	// follow the changes of the cluster before adding one after them
+	n.refreshRegions()
+	// every change of the registry seen by this node is older
+	update := nodemeta.Registry{Version: n.regions.get().Version + 1, Changed: make(map[uint]uint64)}
+	for _, region := range req.Register {
+		update.Regions = append(update.Regions, uint(region))
+		update.Changed[uint(region)] = update.Version
+	}
+	for _, region := range req.Retire {
+		update.Retired = append(update.Retired, uint(region))
+		update.Changed[uint(region)] = update.Version
+	}
+	n.regions.adopt(update)
+	n.saveRegions()
+	reg := n.regions.get()
+	n.logger.Info("updated region registry", "version", reg.Version,
+		"registered", reg.Regions, "retired", reg.Retired)
+	n.announceRegistry(reg)
+	n.refreshRegions()
+	n.members.touch()
+	return n.GetRegions(ctx, &rpc.GetRegionsRequest{})
+}
+
+func sortedRegions(set map[uint]bool) []uint {
+	regions := make([]uint, 0, len(set))
+	for region := range set {
+		regions = append(regions, region)
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i] < regions[j] })
+	return regions
+}
+
+func toInt32s(regions []uint) []int32 {
+	out := make([]int32, len(regions))
+	for i, region := range regions {
+		out[i] = int32(region)
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package replicator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+)
+
+func TestRegionRegistryAdopt(t *testing.T) {
+	r := newRegionRegistry([]uint{2, 1, 0, 2})
+	if got := r.get(); got.Version != 0 || !reflect.DeepEqual(got.Regions, []uint{1, 2}) {
+		t.Fatalf("started from %+v, want regions 1 and 2 at version 0", got)
+	}
+
+	v1 := nodemeta.Registry{Version: 1, Regions: []uint{3}, Changed: map[uint]uint64{3: 1}}
+	if !r.adopt(v1) {
+		t.Fatal("a registry registering a new region was not adopted")
+	}
+	if got := r.get(); !reflect.DeepEqual(got.Regions, []uint{1, 2, 3}) || got.Version != 1 {
+		t.Fatalf("following %+v, want regions 1 to 3 at version 1", got)
+	}
+	if r.adopt(nodemeta.Registry{Regions: []uint{1}}) {
+		t.Fatal("an older registry changed the one followed")
+	}
+	if r.adopt(v1) {
+		t.Fatal("the same registry was adopted again")
+	}
+
+	// concurrent updates with the same version settle the same way
+	// whichever arrives first, retirements winning
+	a := nodemeta.Registry{Version: 2, Retired: []uint{3}, Changed: map[uint]uint64{3: 2}}
+	b := nodemeta.Registry{Version: 2, Regions: []uint{3, 4}, Changed: map[uint]uint64{3: 2, 4: 2}}
+	want := nodemeta.Registry{Version: 2, Regions: []uint{4}, Retired: []uint{3}, Changed: map[uint]uint64{3: 2, 4: 2}}
+	for _, order := range [][]nodemeta.Registry{{a, b}, {b, a}} {
+		r := newRegionRegistry(nil)
+		for _, reg := range order {
+			r.adopt(reg)
+		}
+		if got := r.get(); !got.Equal(want) {
+			t.Errorf("adopted %+v then %+v: following %+v, want %+v", order[0], order[1], got, want)
+		}
+	}
+}
+
+func TestRegionRegistryResolve(t *testing.T) {
+	r := newRegionRegistry([]uint{1, 2})
+	alive := func(regions ...uint) map[uint]bool {
+		m := make(map[uint]bool)
+		for _, region := range regions {
+			m[region] = true
+		}
+		return m
+	}
+
+	// registered regions count while none of their nodes is up
+	if got := r.resolve(alive(1)); !reflect.DeepEqual(got, []uint{1, 2}) {
+		t.Fatalf("resolved %v, want the registered regions", got)
+	}
+
+	// a new region is learned from its nodes and still counts once they are gone
+	if got := r.resolve(alive(1, 2, 3)); !reflect.DeepEqual(got, []uint{1, 2, 3}) {
+		t.Fatalf("resolved %v with region 3 up, want it learned", got)
+	}
+	if got := r.resolve(alive(1)); !reflect.DeepEqual(got, []uint{1, 2, 3}) {
+		t.Fatalf("resolved %v with region 3 down, want it still counted", got)
+	}
+
+	// a retired region counts only while its nodes are up
+	r.adopt(nodemeta.Registry{Version: 1, Retired: []uint{2, 3}, Changed: map[uint]uint64{2: 1, 3: 1}})
+	if got := r.resolve(alive(1, 3)); !reflect.DeepEqual(got, []uint{1, 3}) {
+		t.Fatalf("resolved %v with retired region 3 up, want it counted", got)
+	}
+	if got := r.resolve(alive(1)); !reflect.DeepEqual(got, []uint{1}) {
+		t.Fatalf("resolved %v once retired regions are down, want only region 1", got)
+	}
+
+	// registering a retired region again makes it count for good
+	r.adopt(nodemeta.Registry{Version: 2, Regions: []uint{3}, Changed: map[uint]uint64{3: 2}})
+	if got := r.resolve(alive(1)); !reflect.DeepEqual(got, []uint{1, 3}) {
+		t.Fatalf("resolved %v, want region 3 registered again", got)
+	}
+}
+
+func TestRegionRegistryRestore(t *testing.T) {
+	r := newRegionRegistry([]uint{1})
+	r.adopt(nodemeta.Registry{Version: 1, Retired: []uint{2}, Changed: map[uint]uint64{2: 1}})
+	r.resolve(map[uint]bool{1: true, 2: true, 3: true})
+	saved, ok := r.unsaved()
+	if !ok {
+		t.Fatal("changes were not reported unsaved")
+	}
+	if _, ok := r.unsaved(); ok {
+		t.Fatal("reported unsaved again without a change")
+	}
+
+	// a restarted node follows the registry and counts the regions learned
+	// before, not the retired ones
+	restored := newRegionRegistry([]uint{1})
+	restored.restore(saved)
+	if got := restored.get(); !got.Equal(r.get()) {
+		t.Fatalf("restored %+v, want %+v", got, r.get())
+	}
+	if got := restored.resolve(nil); !reflect.DeepEqual(got, []uint{1, 3}) {
+		t.Fatalf("resolved %v after restoring, want regions 1 and 3", got)
+	}
+}
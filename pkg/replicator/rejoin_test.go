@@ -0,0 +1,116 @@
+package replicator_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+)
+
+// startNode starts a node gossiping through sb, shut down when the test ends.
+func startNode(t *testing.T, sb *replicatortest.Switchboard, name string, region uint, opts ...replicator.Option) (*replicator.Node, chan error) {
+	t.Helper()
+	tr, err := sb.Transport(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts = append([]replicator.Option{
+		replicator.WithTransport(tr),
+		replicator.WithLogger(logging.Nop()),
+		replicator.WithMemberlistConfig(func(mc *memberlist.Config) {
+			mc.ProbeInterval = 200 * time.Millisecond
+			mc.GossipInterval = 50 * time.Millisecond
+			mc.PushPullInterval = time.Second
+			mc.TCPTimeout = time.Second
+		}),
+	}, opts...)
+	n := replicator.NewNode(name, region, "127.0.0.1", 0, 0, "", opts...)
+	errs := n.Start()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		n.Shutdown(ctx)
+	})
+	return n, errs
+}
+
+// unusedAddr returns the gossip address node name will have, without
+// anything answering on it yet.
+func unusedAddr(t *testing.T, sb *replicatortest.Switchboard, name string) string {
+	t.Helper()
+	tr, err := sb.Transport(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.Shutdown()
+	return sb.Addr(name)
+}
+
+func TestJoinBackoff(t *testing.T) {
+	sb := replicatortest.NewSwitchboard(1)
+	seed := unusedAddr(t, sb, "seed")
+
+	// waits 50ms, then 60ms twice, instead of 100ms and 200ms without the cap
+	start := time.Now()
+	_, errs := startNode(t, sb, "a", 1,
+		replicator.WithSeeds(seed),
+		replicator.WithJoinRetry(4, 50*time.Millisecond, 60*time.Millisecond),
+		replicator.WithRejoinInterval(-1))
+	select {
+	case err := <-errs:
+		elapsed := time.Since(start)
+		if err == nil || !strings.Contains(err.Error(), "join cluster") {
+			t.Fatalf("node failed with %v, want a join error", err)
+		}
+		if elapsed < 170*time.Millisecond || elapsed > 330*time.Millisecond {
+			t.Errorf("gave up joining after %s, want about 170ms", elapsed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("node still running after every join attempt failed")
+	}
+}
+
+func TestRejoinWhenAlone(t *testing.T) {
+	sb := replicatortest.NewSwitchboard(1)
+	seed := unusedAddr(t, sb, "b")
+
+	a, errs := startNode(t, sb, "a", 1,
+		replicator.WithSeeds(seed),
+		replicator.WithJoinRetry(1, time.Millisecond, time.Millisecond),
+		replicator.WithRejoinInterval(100*time.Millisecond))
+	// running alone once the only attempt failed
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case err := <-errs:
+		t.Fatalf("node failed: %v", err)
+	default:
+	}
+
+	startNode(t, sb, "b", 2)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		resp, err := a.ListMembers(context.Background(), &rpc.ListMembersRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var alive int
+		for _, m := range resp.Members {
+			if m.State == "alive" {
+				alive++
+			}
+		}
+		if alive == 2 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d members alive, want the seed that came up later to be joined", alive)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,302 @@
+package replicator_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+)
+
+func TestReplicationConverges(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+
+	for i := 0; i < c.Len(); i++ {
+		c.Put(i, fmt.Sprint("key-", i), fmt.Sprint("from node ", i))
+	}
+	c.WaitConverged("key-0", "key-1", "key-2")
+
+	for i := 0; i < c.Len(); i++ {
+		ev, err := c.Get(i, "key-1")
+		if err != nil {
+			t.Fatalf("node %d: %v", i, err)
+		}
+		if ev.Data != "from node 1" {
+			t.Fatalf("node %d: data = %q", i, ev.Data)
+		}
+	}
+}
+
+func TestPartitionHeals(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+
+	c.Partition([]int{0}, []int{1, 2})
+	c.Put(0, "left", "written on the minority side")
+	c.Put(1, "right", "written on the majority side")
+	c.WaitConvergedAmong([]int{1, 2}, "right")
+	time.Sleep(time.Second)
+	if _, err := c.Get(2, "left"); err == nil {
+		t.Fatal("a write crossed the partition")
+	}
+
+	c.Heal()
+	c.WaitMembers()
+	c.WaitConverged("left", "right")
+}
+
+func TestConcurrentWritesAcrossPartition(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+
+	c.Partition([]int{0}, []int{1, 2})
+	c.Put(0, "k", "a")
+	c.Put(1, "k", "b")
+	c.Heal()
+	c.WaitConverged("k")
+
+	ev, err := c.Get(2, "k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ev.Siblings) != 1 {
+		t.Fatalf("concurrent writes kept %d siblings, want 1", len(ev.Siblings))
+	}
+}
+
+func TestDelayedLinks(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+
+	c.DelayAll(150 * time.Millisecond)
+	c.Put(0, "k", "v1")
+	c.Put(0, "k", "v2")
+	c.WaitConverged("k")
+
+	ev, err := c.Get(1, "k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Data != "v2" {
+		t.Fatalf("data = %q, want v2", ev.Data)
+	}
+}
+
+func TestCrashAndRestart(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+
+	c.Put(0, "before", "v")
+	c.WaitConverged("before")
+
+	c.Crash(2)
+	c.WaitMembers()
+	c.Put(0, "during", "v")
+	c.WaitConverged("during")
+
+	c.Restart(2)
+	c.WaitMembers()
+	c.WaitConverged("before", "during")
+}
+
+func TestCommittedEventsAreDeleted(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3})
+
+	c.Put(0, "k", "v")
+	c.WaitFor(func() (bool, string) {
+		for i := 0; i < c.Len(); i++ {
+			if _, err := c.Get(i, "k"); err == nil {
+				return false, fmt.Sprintf("node %d still holds the event", i)
+			}
+		}
+		return true, ""
+	})
+}
+
+// TestCommittedEventsAreDeletedAmongOthers checks that the last copy of a
+// committed event goes even though peers still hold other events, here
+// tombstones within their grace period.
+func TestCommittedEventsAreDeletedAmongOthers(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3})
+
+	c.Put(1, "other", "v")
+	c.Delete(1, "other")
+	c.Put(0, "k", "v")
+	c.WaitFor(func() (bool, string) {
+		for i := 0; i < c.Len(); i++ {
+			if _, err := c.Get(i, "k"); err == nil {
+				return false, fmt.Sprintf("node %d still holds the event", i)
+			}
+		}
+		return true, ""
+	})
+}
+
+func TestLossyLinks(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+
+	sb := c.Switchboard()
+	for i := 0; i < c.Len(); i++ {
+		for j := 0; j < c.Len(); j++ {
+			if i != j {
+				sb.SetRule(c.Name(i), c.Name(j), replicatortest.Rule{
+					Drop:      0.2,
+					Duplicate: 0.3,
+					Reorder:   50 * time.Millisecond,
+				})
+			}
+		}
+	}
+	var ids []string
+	for k := 0; k < 10; k++ {
+		id := fmt.Sprint("key-", k)
+		c.Put(k%c.Len(), id, "v1")
+		c.Put(k%c.Len(), id, "v2")
+		ids = append(ids, id)
+	}
+	c.WaitConverged(ids...)
+
+	for _, id := range ids {
+		ev, err := c.Get(0, id)
+		if err != nil {
+			t.Fatalf("%s: %v", id, err)
+		}
+		if ev.Data != "v2" || len(ev.Siblings) != 0 {
+			t.Fatalf("%s: data = %q with %d siblings, want v2 alone", id, ev.Data, len(ev.Siblings))
+		}
+	}
+}
+
+// TestRandomFaultsHistory runs random puts, gets and deletes while
+// partitioning, degrading and crashing nodes, then checks the history once
+// the faults are gone. A failure prints the seed to replay it with.
+func TestRandomFaultsHistory(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+	rnd := rand.New(rand.NewSource(c.Seed()))
+	sb := c.Switchboard()
+
+	crashed := -1
+	running := func() int {
+		for {
+			i := rnd.Intn(c.Len())
+			if i != crashed {
+				return i
+			}
+		}
+	}
+	for step := 0; step < 120; step++ {
+		if step%15 == 0 {
+			switch rnd.Intn(4) {
+			case 0:
+				i := rnd.Intn(c.Len())
+				c.Partition([]int{i})
+			case 1:
+				for i := 0; i < c.Len(); i++ {
+					for j := 0; j < c.Len(); j++ {
+						if i != j {
+							sb.SetRule(c.Name(i), c.Name(j), replicatortest.Rule{
+								Drop:      0.3,
+								Duplicate: 0.2,
+								Delay:     10 * time.Millisecond,
+								Reorder:   30 * time.Millisecond,
+							})
+						}
+					}
+				}
+			case 2:
+				if crashed < 0 {
+					crashed = rnd.Intn(c.Len())
+					c.Crash(crashed)
+				} else {
+					c.Restart(crashed)
+					crashed = -1
+				}
+			default:
+				c.Heal()
+			}
+		}
+
+		id := fmt.Sprint("key-", rnd.Intn(4))
+		switch i := running(); rnd.Intn(5) {
+		case 0:
+			c.Delete(i, id)
+		case 1, 2:
+			c.Get(i, id)
+		default:
+			c.Put(i, id, fmt.Sprint("value-", step))
+		}
+		time.Sleep(time.Duration(rnd.Intn(20)) * time.Millisecond)
+	}
+
+	c.Heal()
+	if crashed >= 0 {
+		c.Restart(crashed)
+	}
+	c.WaitMembers()
+	c.CheckHistory()
+}
+
+// TestShutdownAfterDeadline checks that a node whose shutdown deadline
+// already passed still stops, even if peers cannot hear it leave.
+func TestShutdownAfterDeadline(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 2})
+	c.Partition([]int{0}, []int{1})
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		c.Node(1).Shutdown(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("shutdown still waiting for peers to hear the node leave")
+	}
+}
+
+func TestConcurrentRegistryUpdates(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3})
+	ctx := context.Background()
+	update := func(i int, req *rpc.UpdateRegionsRequest) *rpc.RegionRegistry {
+		t.Helper()
+		reg, err := c.Node(i).UpdateRegions(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reg
+	}
+	// every node follows the registry at version, registering and retiring
+	agree := func(version uint64, registered, retired []int32) (bool, string) {
+		for i := 0; i < c.Len(); i++ {
+			reg, _ := c.Node(i).GetRegions(ctx, &rpc.GetRegionsRequest{})
+			if reg.Version != version || fmt.Sprint(reg.Registered) != fmt.Sprint(registered) ||
+				fmt.Sprint(reg.Retired) != fmt.Sprint(retired) {
+				return false, fmt.Sprintf("%s follows version %d registering %v and retiring %v, want version %d registering %v and retiring %v",
+					c.Name(i), reg.Version, reg.Registered, reg.Retired, version, registered, retired)
+			}
+		}
+		return true, ""
+	}
+
+	c.Partition([]int{0}, []int{1, 2})
+	if reg := update(0, &rpc.UpdateRegionsRequest{Register: []int32{7}}); reg.Version != 1 {
+		t.Fatalf("first update got version %d, want 1", reg.Version)
+	}
+	update(1, &rpc.UpdateRegionsRequest{Register: []int32{8}})
+	// and a later one on the same side, before hearing of region 7
+	c.WaitFor(func() (bool, string) {
+		reg, _ := c.Node(2).GetRegions(ctx, &rpc.GetRegionsRequest{})
+		return reg.Version == 1, fmt.Sprintf("%s follows version %d, want the update of %s", c.Name(2), reg.Version, c.Name(1))
+	})
+	update(2, &rpc.UpdateRegionsRequest{Register: []int32{9}, Retire: []int32{8}})
+	c.Heal()
+	// no update is lost, the retirement of 8 being the latest change
+	c.WaitFor(func() (bool, string) { return agree(2, []int32{1, 2, 3, 7, 9}, []int32{8}) })
+
+	// the next update follows every update seen
+	if reg := update(0, &rpc.UpdateRegionsRequest{Register: []int32{8}}); reg.Version != 3 {
+		t.Fatalf("third update got version %d, want 3", reg.Version)
+	}
+	c.WaitFor(func() (bool, string) { return agree(3, []int32{1, 2, 3, 7, 8, 9}, nil) })
+}
@@ -4,73 +4,191 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"net"
 	"net/http"
-	"os"
+	"runtime/debug"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/memberlist"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/hlc"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
 	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
 	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/twitchtv/twirp"
 )
 
+const (
+	// defaultStatsInterval is how often the event gauges of the metrics are counted
+	defaultStatsInterval = 15 * time.Second
+
+	// defaultLeaveTimeout bounds how long leaving the cluster waits when Shutdown has no deadline
+	defaultLeaveTimeout = 15 * time.Second
+
+	// minLeaveTimeout bounds how long leaving the cluster waits once the Shutdown deadline passed
+	minLeaveTimeout = time.Second
+
+	// announceLeaveTimeout bounds how long Shutdown waits for peers to learn the node is leaving
+	announceLeaveTimeout = 2 * time.Second
+)
+
 type Node struct {
 
 	// host and node ports for gossiping and api
 	addr    string
 	apiPort int
 
-	// addr:port of any node in the cluster to join to; empty if it's the first node
-	clusterNodeAddr string
+	// how to find and join the cluster; no seeds if it's the first node
+	join joinConfig
+
+	// Holds the node data state
+	storage storage.Backend
 
-	// Holds the node data state; it's also the Delegate used by memberlist to gossip state
-	storage *storage.InMemoryStorage
+	// Delegate used by memberlist to gossip the storage state
+	delegate *storage.Delegate
 
 	memberConfig *memberlist.Config
-	memberlist   *memberlist.Memberlist
 
-	regionID        uint
-	numberOfRegions uint
+	// guards memberlist, created in the background by joinCluster
+	mu         sync.Mutex
+	memberlist *memberlist.Memberlist
+
+	// the address peers gossip with the node on, set with memberlist
+	gossipAddr string
+
+	regionID uint
+
+	// regions that must commit an event, following the registry and the membership
+	regions *regionRegistry
+
+	// regions registered until the registry is updated, see WithRegions
+	registered []uint
+
+	// how long acknowledged tombstones are kept before being collected
+	tombstoneGracePeriod time.Duration
+
+	// how far ahead of the local clock the timestamps of peers may be
+	maxClockOffset time.Duration
+
+	// how often the event gauges of the metrics are counted
+	statsInterval time.Duration
+
+	// how many keys a ListEvents call examines at most
+	listScanLimit int
 
 	httpServer *http.Server
+
+	// closed on Shutdown to stop background loops
+	stop         chan struct{}
+	shutdownOnce sync.Once
+	// the background loops reading the storage, waited for before closing it
+	loops sync.WaitGroup
+
+	// errors preventing the node from running, returned by Start
+	errs chan error
+
+	// set when NewNode could not set the node up, reported by Start
+	initErr error
+
+	logger logging.Logger
+
+	// exported on /metrics next to the API
+	metrics *metrics
+
+	// every member seen, including the ones that left or died
+	members *membership
+
+	// what the node tells peers about itself
+	meta nodemeta.Meta
 }
 
-func NewNode(name string, regionID uint, numberOfRegions uint, addr string, apiPort, gossipPort int, clusterNodeAddr string) *Node {
+func NewNode(name string, regionID uint, addr string, apiPort, gossipPort int, clusterNodeAddr string, opts ...Option) *Node {
 	config := memberlist.DefaultLocalConfig()
 	config.Name = name
 	config.BindAddr = addr
 	config.BindPort = gossipPort
 	config.AdvertisePort = config.BindPort
 
-	md := make(map[string]string, 1)
-	md["apiPort"] = strconv.Itoa(apiPort)
+	n := &Node{
+		addr:         addr,
+		apiPort:      apiPort,
+		memberConfig: config,
+		regionID:     regionID,
 
-	backendStorage := storage.NewInMemoryDB(md, regionID, numberOfRegions)
-	config.Delegate = backendStorage
+		join:                 defaultJoinConfig(),
+		tombstoneGracePeriod: storage.DefaultTombstoneGracePeriod,
+		maxClockOffset:       hlc.DefaultMaxOffset,
+		statsInterval:        defaultStatsInterval,
+		listScanLimit:        defaultListScanLimit,
+		stop:                 make(chan struct{}),
+		errs:                 make(chan error, 1),
+		logger:               logging.Default(),
+		meta: nodemeta.Meta{
+			Region:       regionID,
+			Protocol:     storage.ProtocolVersion,
+			Role:         nodemeta.RoleReplica,
+			BuildVersion: defaultBuildVersion(),
+			// the API listens on every interface, peers reach it on the gossip address
+			APIAddr: net.JoinHostPort("", strconv.Itoa(apiPort)),
+		},
+	}
+	if clusterNodeAddr != "" {
+		n.join.seeds = append(n.join.seeds, clusterNodeAddr)
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	n.regions = newRegionRegistry(append(n.registered, regionID))
+	n.logger = logging.With(n.logger, "node", name, "region", regionID)
+	config.Logger = logging.StdLog(n.logger, "memberlist")
+	if n.storage == nil {
+		db, err := storage.NewBadgerDB(storage.Options{InMemory: true, Logger: n.logger})
+		if err != nil {
+			n.initErr = fmt.Errorf("open in-memory storage: %w", err)
+		} else {
+			n.storage = db
+		}
+	}
 
-	return &Node{
-		addr:            addr,
-		apiPort:         apiPort,
-		clusterNodeAddr: clusterNodeAddr,
-		storage:         backendStorage,
-		memberConfig:    config,
-		regionID:        regionID,
-		numberOfRegions: numberOfRegions,
+	n.meta.Registry = n.regions.get()
+	n.delegate = storage.NewDelegate(n.storage, n.meta, regionID, n.meta.Registry.Regions)
+	n.delegate.SetLogger(n.logger)
+	n.delegate.SetTombstoneGracePeriod(n.tombstoneGracePeriod)
+	n.delegate.SetMaxClockOffset(n.maxClockOffset)
+	n.metrics = newMetrics(n)
+	n.delegate.SetObserver(n.metrics)
+	n.members = newMembership(name, n.delegate, n.logger)
+	config.Events = n.members
+	config.Delegate = n.delegate
+	return n
+}
+
+// defaultBuildVersion is the module version of the running binary, if known.
+func defaultBuildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
 	}
+	return info.Main.Version
 }
 
+// errReservedID is returned for event ids the node keeps its own state under.
+var errReservedID = twirp.InvalidArgumentError("id", "must not start with a NUL byte")
+
 // Put adds config to the local store
 func (n *Node) Put(ctx context.Context, req *rpc.PutEventRequest) (*rpc.Event, error) {
-	key := req.Id
-	regions := make(map[uint]bool)
-	regions[n.regionID] = true
+	if storage.IsLocal(req.Id) {
+		return nil, errReservedID
+	}
 	meta := storage.Meta{
-		Version:         int(req.Version),
-		SourceRegion:    int(req.SourceRegion),
-		SVCCode:         req.ServiceCode,
-		CommitedRegions: regions,
+		Version:      int(req.Version),
+		SourceRegion: int(req.SourceRegion),
+		SVCCode:      req.ServiceCode,
 	}
 
 	v := storage.V{
@@ -80,52 +198,102 @@ func (n *Node) Put(ctx context.Context, req *rpc.PutEventRequest) (*rpc.Event, e
 		Meta:       meta,
 	}
 
-	val, err := json.Marshal(v)
+	// update local state and gossip it to peers
+	v, err := n.delegate.Write(v, nil)
 	if err != nil {
 		return nil, err
 	}
+	n.logger.Debug("put event", "key", req.Id, "version", v.Meta.Version, "data", logging.Payload(v.Data))
 
-	// update local state
-	err = n.storage.Put(key, val)
-	if err != nil {
-		return nil, err
-	}
-	log.Println("succesfully put config", req.Id, v)
-
-	var commitedRegions []*rpc.Pair
-	for k, v := range meta.CommitedRegions {
-		pair := &rpc.Pair{
-			Key:   int32(k),
-			Value: v,
-		}
-		commitedRegions = append(commitedRegions, pair)
-	}
-	return &rpc.Event{Id: key, Data: string(v.Data),
-		ActionName: v.ActionName,
-		Meta: &rpc.Meta{
-			Version:         int32(meta.Version),
-			SourceRegion:    int32(meta.SourceRegion),
-			ServiceCode:     meta.SVCCode,
-			CommitedRegions: &rpc.Dictionary{Pairs: commitedRegions},
-		}}, nil
+	return toEvent(v), nil
 }
 
 // Get fetches config from the local store
 func (n *Node) Get(ctx context.Context, req *rpc.GetEventRequest) (*rpc.Event, error) {
 	key := req.Id
+	if storage.IsLocal(key) {
+		return nil, twirp.NotFoundError("event not found")
+	}
 	b, err := n.storage.Get(key)
 	if err != nil {
-		log.Println("failed to get from storage", key, err)
+		if err == storage.ErrKeyNotFound {
+			return nil, twirp.NotFoundError("event not found")
+		}
+		n.logger.Error("failed to get event", "key", key, "error", err)
 		return nil, err
 	}
 
 	var v storage.V
 	err = json.Unmarshal(b, &v)
 	if err != nil {
-		log.Println("failed to marshal from storage", key, err)
+		n.logger.Error("failed to decode stored event", "key", key, "error", err)
 		return nil, err
 	}
+	if v.Deleted() {
+		return nil, twirp.NotFoundError("event not found")
+	}
+
+	return toEvent(v), nil
+}
 
+// Delete replaces an event with a tombstone that replicates to every region
+func (n *Node) Delete(ctx context.Context, req *rpc.DeleteEventRequest) (*rpc.Event, error) {
+	if req.Id == "" {
+		return nil, twirp.RequiredArgumentError("id")
+	}
+	if storage.IsLocal(req.Id) {
+		return nil, errReservedID
+	}
+	v, err := n.delegate.Delete(req.Id, int(req.SourceRegion), int(req.Version), time.Now())
+	if err != nil {
+		n.logger.Error("failed to delete event", "key", req.Id, "error", err)
+		return nil, err
+	}
+	n.logger.Debug("deleted event", "key", req.Id, "version", v.Meta.Version)
+
+	return toEvent(v), nil
+}
+
+// Resolve writes the value an application chose for a conflicted event.
+// The request context is the one returned by Get; it lists the versions the
+// resolution supersedes, so a conflicting write arriving meanwhile is kept.
+func (n *Node) Resolve(ctx context.Context, req *rpc.ResolveEventRequest) (*rpc.Event, error) {
+	if req.Id == "" {
+		return nil, twirp.RequiredArgumentError("id")
+	}
+	if storage.IsLocal(req.Id) {
+		return nil, errReservedID
+	}
+	if len(req.Context) == 0 {
+		return nil, twirp.RequiredArgumentError("context")
+	}
+	vector := make(storage.VersionVector, len(req.Context))
+	for _, e := range req.Context {
+		vector[uint(e.Region)] = e.Counter
+	}
+
+	v := storage.V{
+		ID:         req.Id,
+		ActionName: req.ActionName,
+		Data:       []byte(req.Data),
+		Meta: storage.Meta{
+			Version:      int(req.Version),
+			SourceRegion: int(req.SourceRegion),
+			SVCCode:      req.ServiceCode,
+		},
+	}
+	v, err := n.delegate.Write(v, vector)
+	if err != nil {
+		n.logger.Error("failed to resolve event", "key", req.Id, "error", err)
+		return nil, err
+	}
+	n.logger.Debug("resolved event", "key", req.Id, "version", v.Meta.Version, "data", logging.Payload(v.Data))
+
+	return toEvent(v), nil
+}
+
+// toEvent converts a stored value to its API representation
+func toEvent(v storage.V) *rpc.Event {
 	var commitedRegions []*rpc.Pair
 	for k, v := range v.Meta.CommitedRegions {
 		pair := &rpc.Pair{
@@ -134,71 +302,207 @@ func (n *Node) Get(ctx context.Context, req *rpc.GetEventRequest) (*rpc.Event, e
 		}
 		commitedRegions = append(commitedRegions, pair)
 	}
-	return &rpc.Event{Id: key,
+	event := &rpc.Event{Id: v.ID,
 		ActionName: v.ActionName,
 		Data:       string(v.Data), Meta: &rpc.Meta{
 			Version:         int32(v.Meta.Version),
 			SourceRegion:    int32(v.Meta.SourceRegion),
 			ServiceCode:     v.Meta.SVCCode,
 			CommitedRegions: &rpc.Dictionary{Pairs: commitedRegions},
-		}}, nil
+			Tombstone:       v.Meta.Tombstone,
+			DeletedAt:       v.Meta.DeletedAt,
+			Timestamp: &rpc.Timestamp{
+				WallTime: v.Meta.HLC.WallTime,
+				Logical:  v.Meta.HLC.Logical,
+				Region:   int32(v.Meta.HLC.Region),
+			},
+			Vector: toVector(v.Meta.Vector),
+		}}
+	for _, sibling := range v.Siblings {
+		sibling.ID = v.ID
+		event.Siblings = append(event.Siblings, toEvent(sibling))
+	}
+	if len(v.Siblings) > 0 {
+		event.Context = toVector(v.Context())
+	}
+	return event
+}
+
+// toVector converts a version vector to its API representation, ordered by region
+func toVector(vv storage.VersionVector) []*rpc.VectorEntry {
+	var entries []*rpc.VectorEntry
+	for region, counter := range vv {
+		entries = append(entries, &rpc.VectorEntry{Region: int32(region), Counter: counter})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Region < entries[j].Region
+	})
+	return entries
 }
 
-// Start async runs gRPC server and joins cluster
+// Start reloads the region registry and the events stored by a previous
+// run, then runs the API server and joins the cluster in the background.
+// The returned channel receives the errors that keep the node from running,
+// such as the API port being taken or the cluster being unreachable; the
+// node is not stopped.
 func (n *Node) Start() chan error {
-	errChan := make(chan error)
-	go n.serve(errChan)
-	go n.joinCluster(errChan)
-	return errChan
+	if n.initErr != nil {
+		n.report(n.initErr)
+		return n.errs
+	}
+	// reload what survived the last run before taking writes or gossiping,
+	// so that new writes are stamped after the stored ones
+	if err := n.loadRegions(); err != nil {
+		n.report(fmt.Errorf("load region registry: %w", err))
+		return n.errs
+	}
+	total, pending, err := n.delegate.Recover()
+	if err != nil {
+		n.report(fmt.Errorf("recover stored events: %w", err))
+		return n.errs
+	}
+	if total > 0 {
+		n.logger.Info("recovered stored events", "events", total, "pending", pending)
+	}
+	n.serve()
+	go n.joinCluster()
+	n.loops.Add(3)
+	go n.collectTombstones()
+	go n.refreshStats()
+	go n.watchTopology()
+	return n.errs
 }
 
-// Shutdown stops gRPC server and leaves cluster
-func (n *Node) Shutdown() {
-	//n.twirpServer.GracefulStop()
-	n.memberlist.Leave(15 * time.Second)
-	n.memberlist.Shutdown()
+// report hands err to whoever watches the channel returned by Start. Only
+// the first error is kept until it is read; the others are logged.
+func (n *Node) report(err error) {
+	select {
+	case n.errs <- err:
+	default:
+		n.logger.Error("node error", "error", err)
+	}
+}
+
+// Shutdown stops the node: it drains in-flight API requests, pushes the
+// events still waiting for other regions to its peers, leaves the cluster
+// and closes the storage. The context bounds the whole sequence; every step
+// runs even if a previous one failed, and the errors are returned together.
+func (n *Node) Shutdown(ctx context.Context) error {
+	var result *multierror.Error
+	n.shutdownOnce.Do(func() {
+		n.mu.Lock()
+		close(n.stop)
+		ml := n.memberlist
+		n.mu.Unlock()
+
+		if n.httpServer != nil {
+			err := n.httpServer.Shutdown(ctx)
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("stop api server: %w", err))
+			}
+		}
+
+		if ml != nil {
+			// tell peers this node leaves on purpose rather than failing
+			n.delegate.SetMetadata(metaState, stateLeaving)
+			err := ml.UpdateNode(announceLeaveTimeout)
+			if err != nil {
+				n.logger.Warn("failed to announce leaving", "error", err)
+			}
+
+			sent, err := n.delegate.Flush(ctx)
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("final state sync: %w", err))
+			} else if sent > 0 {
+				n.logger.Info("pushed pending events to peers", "events", sent)
+			}
+
+			timeout := defaultLeaveTimeout
+			if deadline, ok := ctx.Deadline(); ok {
+				timeout = time.Until(deadline)
+			}
+			if timeout < minLeaveTimeout {
+				// still tell peers, even once ctx expired
+				timeout = minLeaveTimeout
+			}
+			err = ml.Leave(timeout)
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("leave cluster: %w", err))
+			}
+			err = ml.Shutdown()
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("stop gossip: %w", err))
+			}
+		}
+
+		// merges and scans still running would use the storage being closed
+		n.delegate.Stop()
+		n.loops.Wait()
+		if n.storage != nil {
+			err := n.storage.Close()
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("close storage: %w", err))
+			}
+		}
+	})
+	return result.ErrorOrNil()
 }
 
-func (n *Node) serve(errChan chan error) {
+func (n *Node) serve() {
 	n.httpServer = &http.Server{
-		Addr:         fmt.Sprintf(":%d", n.apiPort),
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
 	replicatorHandler := rpc.NewEventReplicatorServiceServer(n,
-		twirp.WithServerPathPrefix("/rz"))
-	n.httpServer.Handler = replicatorHandler
+		twirp.WithServerPathPrefix("/rz"),
+		twirp.WithServerHooks(n.metrics.serverHooks()))
+	mux := http.NewServeMux()
+	mux.Handle(replicatorHandler.PathPrefix(), replicatorHandler)
+	mux.Handle("/metrics", promhttp.HandlerFor(n.metrics.registry, promhttp.HandlerOpts{}))
+	n.httpServer.Handler = mux
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", n.apiPort))
+	if err != nil {
+		n.report(fmt.Errorf("serve api on port %d: %w", n.apiPort, err))
+		return
+	}
+	if n.apiPort == 0 {
+		// tell peers the port picked by the system
+		n.apiPort = ln.Addr().(*net.TCPAddr).Port
+		n.delegate.SetAPIAddr(net.JoinHostPort("", strconv.Itoa(n.apiPort)))
+	}
 	go func() {
-		err := n.httpServer.ListenAndServe()
-		if err != nil {
-			log.Println("Failed to start HTTP server on port : ", n.apiPort, err)
-			os.Exit(-1)
+		err := n.httpServer.Serve(ln)
+		if err != nil && err != http.ErrServerClosed {
+			n.report(fmt.Errorf("serve api on port %d: %w", n.apiPort, err))
 		}
 	}()
-	log.Println("HTTP Server started on port : ", n.apiPort)
+	n.logger.Info("api server started", "port", n.apiPort)
 }
 
-func (n *Node) joinCluster(errChan chan error) {
-	var err error
-	n.memberlist, err = memberlist.Create(n.memberConfig)
-	if err != nil {
-		log.Println("failed to init memberlist", err)
-		errChan <- err
-	}
+// APIPort returns the port serving the API; when NewNode was given port 0,
+// the port picked by the system once the node started.
+func (n *Node) APIPort() int {
+	return n.apiPort
+}
 
-	var nodeAddr string
-	if n.clusterNodeAddr != "" {
-		log.Printf("not the first node, joining %s...", n.clusterNodeAddr)
-		nodeAddr = n.clusterNodeAddr
-	} else {
-		log.Println("first node of the cluster...")
-		nodeAddr = fmt.Sprintf("%s:%d", n.addr, n.memberConfig.BindPort)
+// collectTombstones periodically drops tombstones acknowledged by every region
+func (n *Node) collectTombstones() {
+	defer n.loops.Done()
+	interval := n.tombstoneGracePeriod
+	if interval > time.Minute {
+		interval = time.Minute
 	}
-	_, err = n.memberlist.Join([]string{nodeAddr})
-	if err != nil {
-		log.Println("failed to join cluster", err)
-		errChan <- err
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+			_, err := n.delegate.CollectTombstones(time.Now())
+			if err != nil {
+				n.logger.Error("failed to collect tombstones", "error", err)
+			}
+		}
 	}
-
-	log.Println("succesfully joined cluster via", nodeAddr)
 }
@@ -0,0 +1,632 @@
+// Package replicatortest runs clusters of replicator nodes inside a test
+// process, gossiping through an in-memory Switchboard, and injects faults
+// between them: partitions, loss, delays, duplicates, reordering and
+// crashes.
+//
+//	c := replicatortest.New(t, replicatortest.Config{Nodes: 3})
+//	c.Partition([]int{0}, []int{1, 2})
+//	c.Put(0, "k", "a")
+//	c.Put(1, "k", "b")
+//	c.Heal()
+//	c.WaitConverged("k")
+package replicatortest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultNodes   = 3
+	defaultTimeout = 20 * time.Second
+
+	// pollInterval is how often the waits check the cluster again.
+	pollInterval = 50 * time.Millisecond
+
+	// crashTimeout bounds stopping a crashed node, whose messages are all lost.
+	crashTimeout = 2 * time.Second
+)
+
+// Config describes the cluster to run.
+type Config struct {
+	// Nodes is the number of nodes, 3 if zero.
+	Nodes int
+
+	// Regions is the region of every node; node i is in region i+1 by default.
+	Regions []uint
+
+	// NumberOfRegions registers regions 1 to NumberOfRegions on every node,
+	// see replicator.WithRegions. It defaults to the highest region, so
+	// that events are deleted once every node committed them.
+	NumberOfRegions uint
+
+	// KeepEvents registers one more region, which never joins: events are
+	// then never committed everywhere and stay readable. Tests that read
+	// events after they replicated, and CheckHistory, need it.
+	KeepEvents bool
+
+	// Options are given to every node after the ones of the harness.
+	Options []replicator.Option
+
+	// Logger receives the logs of every node; they are discarded if nil.
+	Logger logging.Logger
+
+	// Timeout bounds every wait of the harness, 20s if zero.
+	Timeout time.Duration
+
+	// Seed drives the random faults of the switchboard, the current time
+	// if zero. It is logged so that a failing run can be replayed.
+	Seed int64
+}
+
+// Cluster is a set of nodes running in the test process. Nodes are
+// identified by their index, from 0 to Len()-1. Methods fail the test
+// instead of returning errors, except Get.
+type Cluster struct {
+	t       testing.TB
+	cfg     Config
+	sb      *Switchboard
+	history *History
+
+	mu    sync.Mutex
+	nodes []*node
+}
+
+type node struct {
+	name    string
+	region  uint
+	backend *durableBackend
+
+	// nil while the node is crashed
+	n    *replicator.Node
+	errs chan error
+}
+
+// durableBackend survives its node being shut down, as a disk would.
+type durableBackend struct {
+	storage.Backend
+}
+
+func (durableBackend) Close() error { return nil }
+
+// New starts a cluster and waits until every node sees all the others.
+// It is shut down when the test ends.
+func New(t testing.TB, cfg Config) *Cluster {
+	t.Helper()
+	if cfg.Nodes == 0 {
+		cfg.Nodes = defaultNodes
+	}
+	if cfg.Regions == nil {
+		for i := 0; i < cfg.Nodes; i++ {
+			cfg.Regions = append(cfg.Regions, uint(i+1))
+		}
+	}
+	if len(cfg.Regions) != cfg.Nodes {
+		t.Fatalf("replicatortest: %d regions for %d nodes", len(cfg.Regions), cfg.Nodes)
+	}
+	if cfg.NumberOfRegions == 0 {
+		for _, region := range cfg.Regions {
+			if region > cfg.NumberOfRegions {
+				cfg.NumberOfRegions = region
+			}
+		}
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = logging.Nop()
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	t.Logf("replicatortest: seed %d", cfg.Seed)
+
+	c := &Cluster{t: t, cfg: cfg, sb: NewSwitchboard(cfg.Seed), history: &History{}}
+	t.Cleanup(c.Close)
+	for i := 0; i < cfg.Nodes; i++ {
+		db, err := storage.NewBadgerDB(storage.Options{InMemory: true, Logger: logging.Nop()})
+		if err != nil {
+			t.Fatalf("replicatortest: open storage: %v", err)
+		}
+		c.nodes = append(c.nodes, &node{
+			name:    "node" + strconv.Itoa(i),
+			region:  cfg.Regions[i],
+			backend: &durableBackend{db},
+		})
+	}
+	for i := range c.nodes {
+		c.start(i)
+	}
+	c.WaitMembers()
+	return c
+}
+
+// start runs node i, joining the nodes running already.
+func (c *Cluster) start(i int) {
+	c.t.Helper()
+	nd := c.nodes[i]
+	tr, err := c.sb.Transport(nd.name)
+	if err != nil {
+		c.t.Fatalf("replicatortest: gossip transport of %s: %v", nd.name, err)
+	}
+
+	var seeds []string
+	for j, other := range c.nodes {
+		if j != i && other.n != nil {
+			seeds = append(seeds, c.sb.Addr(other.name))
+		}
+	}
+	opts := []replicator.Option{
+		replicator.WithBackend(nd.backend),
+		replicator.WithLogger(c.cfg.Logger),
+		replicator.WithSeeds(seeds...),
+		replicator.WithJoinRetry(10, 50*time.Millisecond, time.Second),
+		replicator.WithRejoinInterval(500 * time.Millisecond),
+		replicator.WithTransport(tr),
+		replicator.WithMemberlistConfig(func(mc *memberlist.Config) {
+			mc.ProbeInterval = 200 * time.Millisecond
+			mc.ProbeTimeout = 100 * time.Millisecond
+			mc.SuspicionMult = 2
+			mc.GossipInterval = 50 * time.Millisecond
+			mc.PushPullInterval = time.Second
+			mc.TCPTimeout = time.Second
+			// keep gossiping to the nodes declared dead, so that the two
+			// sides of a partition find each other once it heals
+			mc.GossipToTheDeadTime = time.Hour
+		}),
+	}
+	for region := uint(1); region <= c.cfg.NumberOfRegions; region++ {
+		opts = append(opts, replicator.WithRegions(region))
+	}
+	if c.cfg.KeepEvents {
+		opts = append(opts, replicator.WithRegions(c.cfg.NumberOfRegions+1))
+	}
+	n := replicator.NewNode(nd.name, nd.region, "127.0.0.1", 0, 0, "",
+		append(opts, c.cfg.Options...)...)
+
+	c.mu.Lock()
+	nd.n = n
+	nd.errs = n.Start()
+	c.mu.Unlock()
+}
+
+// Len returns the number of nodes, crashed ones included.
+func (c *Cluster) Len() int {
+	return len(c.nodes)
+}
+
+// Node returns node i, or nil while it is crashed.
+func (c *Cluster) Node(i int) *replicator.Node {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nodes[i].n
+}
+
+// Name returns the name of node i, as known to the Switchboard.
+func (c *Cluster) Name(i int) string {
+	return c.nodes[i].name
+}
+
+// Switchboard returns the network of the cluster, to set finer rules
+// than the methods of Cluster do.
+func (c *Cluster) Switchboard() *Switchboard {
+	return c.sb
+}
+
+// Seed returns the seed of the random faults, to drive a random workload
+// as well.
+func (c *Cluster) Seed() int64 {
+	return c.cfg.Seed
+}
+
+// History returns the operations run through Put, Delete and Get so far.
+func (c *Cluster) History() *History {
+	return c.history
+}
+
+// Region returns the region of node i.
+func (c *Cluster) Region(i int) uint {
+	return c.nodes[i].region
+}
+
+// running returns the indexes of the nodes not crashed.
+func (c *Cluster) running() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var idx []int
+	for i, nd := range c.nodes {
+		if nd.n != nil {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// Partition splits the nodes into groups that cannot reach each other;
+// nodes left out of every group are isolated. It replaces any previous
+// partition.
+func (c *Cluster) Partition(groups ...[]int) {
+	named := make([][]string, len(groups))
+	for g, members := range groups {
+		for _, i := range members {
+			named[g] = append(named[g], c.nodes[i].name)
+		}
+	}
+	c.sb.Partition(named...)
+}
+
+// Heal removes the partition and every fault set on the links.
+func (c *Cluster) Heal() {
+	c.sb.Heal()
+}
+
+// Delay holds what node from sends to node to for d; zero removes the delay.
+func (c *Cluster) Delay(from, to int, d time.Duration) {
+	c.sb.Delay(c.nodes[from].name, c.nodes[to].name, d)
+}
+
+// DelayAll holds every message between two nodes for d.
+func (c *Cluster) DelayAll(d time.Duration) {
+	for i := range c.nodes {
+		for j := range c.nodes {
+			if i != j {
+				c.Delay(i, j, d)
+			}
+		}
+	}
+}
+
+// Crash stops node i without letting it say goodbye: nothing it sends
+// reaches the other nodes, which find out it is dead by themselves. What
+// it stored survives until Restart.
+func (c *Cluster) Crash(i int) {
+	c.t.Helper()
+	c.mu.Lock()
+	nd := c.nodes[i]
+	n := nd.n
+	nd.n = nil
+	c.mu.Unlock()
+	if n == nil {
+		c.t.Fatalf("replicatortest: %s is already crashed", nd.name)
+	}
+
+	c.sb.setDown(nd.name, true)
+	ctx, cancel := context.WithTimeout(context.Background(), crashTimeout)
+	defer cancel()
+	// errors are expected, every peer is unreachable
+	n.Shutdown(ctx)
+}
+
+// Restart starts crashed node i again, on the same gossip address and with
+// what it stored before crashing.
+func (c *Cluster) Restart(i int) {
+	c.t.Helper()
+	if c.Node(i) != nil {
+		c.t.Fatalf("replicatortest: %s is running", c.nodes[i].name)
+	}
+	c.sb.setDown(c.nodes[i].name, false)
+	c.start(i)
+}
+
+// Put writes data under id through node i, on behalf of its region.
+func (c *Cluster) Put(i int, id, data string) *rpc.Event {
+	c.t.Helper()
+	n := c.mustNode(i)
+	start := time.Now()
+	ev, err := n.Put(context.Background(), &rpc.PutEventRequest{
+		Id:           id,
+		Data:         data,
+		SourceRegion: int32(c.nodes[i].region),
+	})
+	c.history.Add(Op{Node: c.nodes[i].name, Kind: OpPut, ID: id, Value: data, Err: err, Start: start, End: time.Now()})
+	if err != nil {
+		c.t.Fatalf("replicatortest: put %s on %s: %v", id, c.nodes[i].name, err)
+	}
+	return ev
+}
+
+// Delete deletes id through node i.
+func (c *Cluster) Delete(i int, id string) {
+	c.t.Helper()
+	n := c.mustNode(i)
+	start := time.Now()
+	_, err := n.Delete(context.Background(), &rpc.DeleteEventRequest{
+		Id:           id,
+		SourceRegion: int32(c.nodes[i].region),
+	})
+	c.history.Add(Op{Node: c.nodes[i].name, Kind: OpDelete, ID: id, Err: err, Start: start, End: time.Now()})
+	if err != nil {
+		c.t.Fatalf("replicatortest: delete %s on %s: %v", id, c.nodes[i].name, err)
+	}
+}
+
+// Get reads id from node i. The error is a twirp.NotFound error when the
+// node does not hold the event.
+func (c *Cluster) Get(i int, id string) (*rpc.Event, error) {
+	c.t.Helper()
+	n := c.mustNode(i)
+	start := time.Now()
+	ev, err := n.Get(context.Background(), &rpc.GetEventRequest{Id: id})
+	op := Op{Node: c.nodes[i].name, Kind: OpGet, ID: id, Start: start, End: time.Now()}
+	if err == nil {
+		op.Read = readOf(ev)
+	} else if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != twirp.NotFound {
+		op.Err = err
+	}
+	c.history.Add(op)
+	return ev, err
+}
+
+// readOf returns the values of ev that are not tombstones, sorted.
+func readOf(ev *rpc.Event) Read {
+	r := Read{Found: true}
+	for _, e := range append([]*rpc.Event{ev}, ev.Siblings...) {
+		if e.Meta == nil || !e.Meta.Tombstone {
+			r.Values = append(r.Values, e.Data)
+		}
+	}
+	sort.Strings(r.Values)
+	return r
+}
+
+func (c *Cluster) mustNode(i int) *replicator.Node {
+	c.t.Helper()
+	n := c.Node(i)
+	if n == nil {
+		c.t.Fatalf("replicatortest: %s is crashed", c.nodes[i].name)
+	}
+	return n
+}
+
+// WaitFor polls cond until it returns true, failing the test with the last
+// description cond returned once the timeout expires, or as soon as a node
+// reports an error.
+func (c *Cluster) WaitFor(cond func() (bool, string)) {
+	c.t.Helper()
+	deadline := time.Now().Add(c.cfg.Timeout)
+	for {
+		c.checkErrors()
+		ok, desc := cond()
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			c.t.Fatalf("replicatortest: timed out after %s: %s", c.cfg.Timeout, desc)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func (c *Cluster) checkErrors() {
+	c.t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, nd := range c.nodes {
+		if nd.n == nil {
+			continue
+		}
+		select {
+		case err := <-nd.errs:
+			c.t.Fatalf("replicatortest: %s failed: %v", nd.name, err)
+		default:
+		}
+	}
+}
+
+// WaitMembers waits until every running node sees all the running nodes
+// alive, and only them.
+func (c *Cluster) WaitMembers() {
+	c.t.Helper()
+	c.WaitFor(func() (bool, string) {
+		running := c.running()
+		want := make([]string, len(running))
+		for k, i := range running {
+			want[k] = c.nodes[i].name
+		}
+		sort.Strings(want)
+		for _, i := range running {
+			n := c.Node(i)
+			if n == nil {
+				return false, c.nodes[i].name + " crashed"
+			}
+			resp, err := n.ListMembers(context.Background(), &rpc.ListMembersRequest{})
+			if err != nil {
+				return false, err.Error()
+			}
+			var alive []string
+			for _, m := range resp.Members {
+				if m.State == "alive" {
+					alive = append(alive, m.Name)
+				}
+			}
+			if strings.Join(alive, ",") != strings.Join(want, ",") {
+				return false, fmt.Sprintf("%s sees %v alive, want %v", c.nodes[i].name, alive, want)
+			}
+		}
+		return true, ""
+	})
+}
+
+// Converged reports whether every running node returns the same event for
+// each of the ids, or none of them holds it. Commit progress is ignored.
+// When they differ, the description lists what every node returns.
+func (c *Cluster) Converged(ids ...string) (bool, string) {
+	return c.ConvergedAmong(c.running(), ids...)
+}
+
+// ConvergedAmong is like Converged for the given nodes only, e.g. one side
+// of a partition.
+func (c *Cluster) ConvergedAmong(nodes []int, ids ...string) (bool, string) {
+	for _, id := range ids {
+		var first *rpc.Event
+		var firstFound, set bool
+		same := true
+		var desc []string
+		for _, i := range nodes {
+			ev, found, err := c.read(i, id)
+			if err != nil {
+				return false, fmt.Sprintf("get %s on %s: %v", id, c.nodes[i].name, err)
+			}
+			desc = append(desc, fmt.Sprintf("%s: %s", c.nodes[i].name, describe(ev, found)))
+			if !set {
+				first, firstFound, set = ev, found, true
+				continue
+			}
+			if found != firstFound || (found && !proto.Equal(ev, first)) {
+				same = false
+			}
+		}
+		if !same {
+			return false, fmt.Sprintf("nodes disagree on %s:\n\t%s", id, strings.Join(desc, "\n\t"))
+		}
+	}
+	return true, ""
+}
+
+// WaitConverged waits until Converged holds for the ids.
+func (c *Cluster) WaitConverged(ids ...string) {
+	c.t.Helper()
+	c.WaitFor(func() (bool, string) {
+		return c.Converged(ids...)
+	})
+}
+
+// WaitConvergedAmong waits until ConvergedAmong holds for the nodes and ids.
+func (c *Cluster) WaitConvergedAmong(nodes []int, ids ...string) {
+	c.t.Helper()
+	c.WaitFor(func() (bool, string) {
+		return c.ConvergedAmong(nodes, ids...)
+	})
+}
+
+// CheckHistory waits until the running nodes agree on every event of the
+// history, or the timeout expires, then checks the history against what
+// they return with Check. Every violation fails the test with a minimal
+// counterexample. The cluster must keep events, see Config.KeepEvents:
+// deleting committed events would look like lost writes.
+func (c *Cluster) CheckHistory() {
+	c.t.Helper()
+	if !c.cfg.KeepEvents {
+		c.t.Fatal("replicatortest: CheckHistory needs Config.KeepEvents")
+	}
+	ops := c.history.Ops()
+	ids := make(map[string]bool)
+	for _, op := range ops {
+		ids[op.ID] = true
+	}
+	deadline := time.Now().Add(c.cfg.Timeout)
+	for {
+		c.checkErrors()
+		ok, _ := c.Converged(sortedSet(ids)...)
+		if ok || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(pollInterval)
+	}
+
+	final := make(State)
+	for id := range ids {
+		final[id] = make(map[string]Read)
+		for _, i := range c.running() {
+			ev, found, err := c.read(i, id)
+			if err != nil {
+				c.t.Fatalf("replicatortest: get %s on %s: %v", id, c.nodes[i].name, err)
+			}
+			r := Read{}
+			if found {
+				r = readOf(ev)
+			}
+			final[id][c.nodes[i].name] = r
+		}
+	}
+	for _, v := range Check(ops, final) {
+		c.t.Errorf("replicatortest: %s", v)
+	}
+}
+
+// read returns the event node i holds for id, without commit progress.
+func (c *Cluster) read(i int, id string) (*rpc.Event, bool, error) {
+	n := c.Node(i)
+	if n == nil {
+		return nil, false, fmt.Errorf("crashed")
+	}
+	ev, err := n.Get(context.Background(), &rpc.GetEventRequest{Id: id})
+	if twerr, ok := err.(twirp.Error); ok && twerr.Code() == twirp.NotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	ev = proto.Clone(ev).(*rpc.Event)
+	withoutCommits(ev)
+	return ev, true, nil
+}
+
+func withoutCommits(ev *rpc.Event) {
+	if ev.Meta != nil {
+		ev.Meta.CommitedRegions = nil
+	}
+	for _, s := range ev.Siblings {
+		withoutCommits(s)
+	}
+}
+
+func describe(ev *rpc.Event, found bool) string {
+	if !found {
+		return "not found"
+	}
+	values := []string{strconv.Quote(ev.Data)}
+	for _, s := range ev.Siblings {
+		values = append(values, strconv.Quote(s.Data))
+	}
+	var vector []string
+	if ev.Meta != nil {
+		for _, e := range ev.Meta.Vector {
+			vector = append(vector, fmt.Sprintf("%d:%d", e.Region, e.Counter))
+		}
+	}
+	return fmt.Sprintf("%s vector=[%s]", strings.Join(values, " | "), strings.Join(vector, " "))
+}
+
+// Close shuts every running node down. It is called when the test ends.
+func (c *Cluster) Close() {
+	c.mu.Lock()
+	var nodes []*replicator.Node
+	for _, nd := range c.nodes {
+		if nd.n != nil {
+			nodes = append(nodes, nd.n)
+			nd.n = nil
+		}
+	}
+	c.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n *replicator.Node) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), crashTimeout)
+			defer cancel()
+			n.Shutdown(ctx)
+		}(n)
+	}
+	wg.Wait()
+	for _, nd := range c.nodes {
+		nd.backend.Backend.Close()
+	}
+}
@@ -0,0 +1,377 @@
+package replicatortest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OpKind is the kind of an operation recorded in a History.
+type OpKind int
+
+const (
+	OpPut OpKind = iota + 1
+	OpGet
+	OpDelete
+)
+
+func (k OpKind) String() string {
+	switch k {
+	case OpPut:
+		return "put"
+	case OpGet:
+		return "get"
+	case OpDelete:
+		return "delete"
+	default:
+		return "op(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// Read is what a node returns for an event: nothing, or its value and the
+// values of its siblings, sorted.
+type Read struct {
+	Found  bool
+	Values []string
+}
+
+func (r Read) String() string {
+	if !r.Found {
+		return "not found"
+	}
+	values := make([]string, len(r.Values))
+	for i, v := range r.Values {
+		values[i] = strconv.Quote(v)
+	}
+	return strings.Join(values, " | ")
+}
+
+func (r Read) contains(value string) bool {
+	if !r.Found {
+		return false
+	}
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Read) equal(o Read) bool {
+	return r.Found == o.Found && strings.Join(r.Values, "\x00") == strings.Join(o.Values, "\x00")
+}
+
+// Op is an operation run against a node of the cluster.
+type Op struct {
+	// Index is the position of the operation in the history, in the order
+	// operations completed.
+	Index int
+
+	Node string
+	Kind OpKind
+	ID   string
+
+	// Value is the data written by a put.
+	Value string
+
+	// Read is what a get returned.
+	Read Read
+
+	// Err is nil if the node acknowledged the operation.
+	Err error
+
+	Start, End time.Time
+}
+
+func (op Op) ok() bool {
+	return op.Err == nil
+}
+
+func (op Op) write() bool {
+	return op.Kind == OpPut || op.Kind == OpDelete
+}
+
+// describe prints the operation, with times relative to since.
+func (op Op) describe(since time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%d %s %s %q", op.Index, op.Node, op.Kind, op.ID)
+	switch op.Kind {
+	case OpPut:
+		fmt.Fprintf(&b, " %q", op.Value)
+	case OpGet:
+		fmt.Fprintf(&b, " -> %s", op.Read)
+	}
+	if op.Err != nil {
+		fmt.Fprintf(&b, " failed: %v", op.Err)
+	}
+	fmt.Fprintf(&b, " [%s, %s]", op.Start.Sub(since).Round(time.Microsecond), op.End.Sub(since).Round(time.Microsecond))
+	return b.String()
+}
+
+// History records the operations run against a cluster. It is safe for
+// concurrent use.
+type History struct {
+	mu  sync.Mutex
+	ops []Op
+}
+
+// Add records op, which completed last.
+func (h *History) Add(op Op) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	op.Index = len(h.ops)
+	h.ops = append(h.ops, op)
+}
+
+// Ops returns the operations recorded so far.
+func (h *History) Ops() []Op {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Op{}, h.ops...)
+}
+
+// State is what every node returns for every event once the cluster is
+// quiet, by event id and then by node name.
+type State map[string]map[string]Read
+
+// Violation is an invariant the cluster broke, with a smallest history
+// that shows it.
+type Violation struct {
+	// Invariant is the broken invariant: divergence, lost write,
+	// resurrection or phantom read.
+	Invariant string
+	ID        string
+	Detail    string
+
+	// Ops is a minimal history: removing any of its operations makes the
+	// violation go away.
+	Ops []Op
+
+	// Final is what every node returns for the event.
+	Final map[string]Read
+
+	// subject tells apart violations of the same invariant on an event.
+	subject string
+}
+
+func (v Violation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s on %q: %s", v.Invariant, v.ID, v.Detail)
+	if len(v.Ops) > 0 {
+		since := v.Ops[0].Start
+		for _, op := range v.Ops {
+			if op.Start.Before(since) {
+				since = op.Start
+			}
+		}
+		b.WriteString("\nhistory:")
+		for _, op := range v.Ops {
+			b.WriteString("\n\t" + op.describe(since))
+		}
+	}
+	b.WriteString("\nfinal:")
+	for _, node := range nodeNames(v.Final) {
+		fmt.Fprintf(&b, "\n\t%s: %s", node, v.Final[node])
+	}
+	return b.String()
+}
+
+// Check verifies the invariants of an eventually consistent store against
+// a history and the state of the nodes once quiet:
+//
+//   - divergence: every node returns the same event;
+//   - lost write: an acknowledged put not followed by another write of
+//     the event is still a value of the event;
+//   - resurrection: a value is gone once an acknowledged delete ran on a
+//     node known to hold it, unless written again;
+//   - phantom read: a get only returns values that were put.
+//
+// Writes are ordered by real time only, so that a write overlapping
+// another one never counts as following it. Every violation comes with a
+// minimal history, found by removing operations as long as the violation
+// remains.
+func Check(ops []Op, final State) []Violation {
+	byID := make(map[string][]Op)
+	for _, op := range ops {
+		byID[op.ID] = append(byID[op.ID], op)
+	}
+	ids := make(map[string]bool)
+	for id := range byID {
+		ids[id] = true
+	}
+	for id := range final {
+		ids[id] = true
+	}
+
+	var out []Violation
+	for _, id := range sortedSet(ids) {
+		for _, v := range checkEvent(id, byID[id], final[id]) {
+			v.Ops = shrink(v, byID[id], final[id])
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// checkEvent checks the invariants for one event.
+func checkEvent(id string, ops []Op, final map[string]Read) []Violation {
+	var out []Violation
+	violation := func(invariant, subject, detail string, args ...interface{}) {
+		out = append(out, Violation{
+			Invariant: invariant,
+			ID:        id,
+			Detail:    fmt.Sprintf(detail, args...),
+			Ops:       ops,
+			Final:     final,
+			subject:   subject,
+		})
+	}
+
+	nodes := nodeNames(final)
+	for _, node := range nodes {
+		if !final[node].equal(final[nodes[0]]) {
+			violation("divergence", "", "nodes disagree after the cluster went quiet")
+			break
+		}
+	}
+
+	for _, p := range ops {
+		if p.Kind != OpPut || !p.ok() || followed(p, ops) {
+			continue
+		}
+		for _, node := range nodes {
+			if !final[node].contains(p.Value) {
+				violation("lost write", strconv.Itoa(p.Index),
+					"%s lost %q, acknowledged by %s and never overwritten", node, p.Value, p.Node)
+				break
+			}
+		}
+	}
+
+	for _, d := range ops {
+		if d.Kind != OpDelete || !d.ok() {
+			continue
+		}
+		for _, value := range deletedValues(d, ops) {
+			for _, node := range nodes {
+				if final[node].contains(value) {
+					violation("resurrection", strconv.Itoa(d.Index)+" "+value,
+						"%s returns %q, deleted through %s", node, value, d.Node)
+					break
+				}
+			}
+		}
+	}
+
+	for _, g := range ops {
+		if g.Kind != OpGet || !g.ok() {
+			continue
+		}
+		for _, value := range g.Read.Values {
+			if !written(value, g.End, ops) {
+				violation("phantom read", strconv.Itoa(g.Index)+" "+value,
+					"%s returned %q, which was never put", g.Node, value)
+			}
+		}
+	}
+	return out
+}
+
+// followed reports whether another write of the event ended after p started.
+// Writes that failed count, since they may have been applied.
+func followed(p Op, ops []Op) bool {
+	for _, w := range ops {
+		if w.Index != p.Index && w.write() && w.End.After(p.Start) {
+			return true
+		}
+	}
+	return false
+}
+
+// deletedValues returns the values d deleted for sure: the ones every put
+// of which the node of d wrote, or read, before d started.
+func deletedValues(d Op, ops []Op) []string {
+	covered := make(map[string]bool)
+	for _, p := range ops {
+		if p.Kind != OpPut {
+			continue
+		}
+		c := p.ok() && p.End.Before(d.Start) && (p.Node == d.Node || seenBefore(p, d, ops))
+		if prev, ok := covered[p.Value]; ok {
+			c = c && prev
+		}
+		covered[p.Value] = c
+	}
+	return sortedSet(covered)
+}
+
+// seenBefore reports whether the node of d read the value put by p after p
+// ended and before d started, so that d covers p.
+func seenBefore(p, d Op, ops []Op) bool {
+	for _, g := range ops {
+		if g.Kind == OpGet && g.ok() && g.Node == d.Node && g.Start.After(p.End) &&
+			g.End.Before(d.Start) && g.Read.contains(p.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// written reports whether a put of value started before t.
+func written(value string, t time.Time, ops []Op) bool {
+	for _, p := range ops {
+		if p.Kind == OpPut && p.Value == value && p.Start.Before(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// shrink removes operations from ops one at a time, as long as v is still
+// found without them.
+func shrink(v Violation, ops []Op, final map[string]Read) []Op {
+	ops = append([]Op{}, ops...)
+	for i := 0; i < len(ops); {
+		rest := append(append([]Op{}, ops[:i]...), ops[i+1:]...)
+		if reported(v, checkEvent(v.ID, rest, final)) {
+			ops = rest
+			continue
+		}
+		i++
+	}
+	return ops
+}
+
+func reported(v Violation, vs []Violation) bool {
+	for _, o := range vs {
+		if o.Invariant == v.Invariant && o.subject == v.subject {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeNames(m map[string]Read) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedSet returns the members of set, sorted.
+func sortedSet(set map[string]bool) []string {
+	var out []string
+	for k, ok := range set {
+		if ok {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
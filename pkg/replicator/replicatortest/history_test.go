@@ -0,0 +1,160 @@
+package replicatortest
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// history builds operations one millisecond apart, in order.
+type history struct {
+	ops []Op
+	now time.Time
+}
+
+func (h *history) add(op Op) *history {
+	op.Index = len(h.ops)
+	op.Start = h.now
+	op.End = h.now.Add(time.Millisecond / 2)
+	h.now = h.now.Add(time.Millisecond)
+	h.ops = append(h.ops, op)
+	return h
+}
+
+func (h *history) put(node, id, value string) *history {
+	return h.add(Op{Node: node, Kind: OpPut, ID: id, Value: value})
+}
+
+func (h *history) del(node, id string) *history {
+	return h.add(Op{Node: node, Kind: OpDelete, ID: id})
+}
+
+func (h *history) get(node, id string, values ...string) *history {
+	return h.add(Op{Node: node, Kind: OpGet, ID: id, Read: Read{Found: len(values) > 0, Values: values}})
+}
+
+func newHistory() *history {
+	return &history{now: time.Unix(0, 0)}
+}
+
+func found(values ...string) Read {
+	return Read{Found: true, Values: values}
+}
+
+func everywhere(r Read) map[string]Read {
+	return map[string]Read{"a": r, "b": r, "c": r}
+}
+
+func indexes(ops []Op) []int {
+	var out []int
+	for _, op := range ops {
+		out = append(out, op.Index)
+	}
+	return out
+}
+
+func TestCheckAccepts(t *testing.T) {
+	h := newHistory().
+		put("a", "k", "v1").
+		get("b", "k", "v1").
+		put("b", "k", "v2"). // overwrites v1
+		put("a", "gone", "x").
+		del("a", "gone").
+		put("c", "conflict", "from c")
+	// written by b before hearing from c: both are kept
+	h.add(Op{Node: "b", Kind: OpPut, ID: "conflict", Value: "from b", Start: h.ops[5].Start, End: h.ops[5].End})
+
+	final := State{
+		"k":        everywhere(found("v2")),
+		"gone":     everywhere(Read{}),
+		"conflict": everywhere(found("from b", "from c")),
+	}
+	if vs := Check(h.ops, final); len(vs) != 0 {
+		t.Fatalf("violations on a valid history:\n%s", vs)
+	}
+}
+
+func TestCheckDivergence(t *testing.T) {
+	h := newHistory().put("a", "k", "v1").put("b", "k", "v2")
+	final := State{"k": {"a": found("v2"), "b": found("v1", "v2")}}
+
+	vs := Check(h.ops, final)
+	if len(vs) != 1 || vs[0].Invariant != "divergence" {
+		t.Fatalf("violations = %v, want a divergence", vs)
+	}
+	if len(vs[0].Ops) != 0 {
+		t.Fatalf("counterexample = %v, want the final state alone", indexes(vs[0].Ops))
+	}
+}
+
+func TestCheckLostWrite(t *testing.T) {
+	h := newHistory().
+		put("a", "k", "v1").
+		get("b", "k", "v1").
+		put("b", "k", "v2").
+		get("c", "k", "v2").
+		put("c", "k", "v3"). // acknowledged, then lost
+		get("a", "k", "v3")
+	final := State{"k": everywhere(found("v2"))}
+
+	vs := Check(h.ops, final)
+	if len(vs) != 1 || vs[0].Invariant != "lost write" {
+		t.Fatalf("violations = %v, want a lost write", vs)
+	}
+	if got := indexes(vs[0].Ops); !reflect.DeepEqual(got, []int{4}) {
+		t.Fatalf("counterexample = %v, want [4]", got)
+	}
+	if !strings.Contains(vs[0].String(), `put "k" "v3"`) {
+		t.Fatalf("counterexample does not show the lost put:\n%s", vs[0])
+	}
+}
+
+func TestCheckFailedWriteIsNotLost(t *testing.T) {
+	h := newHistory().put("a", "k", "v1")
+	h.ops[0].Err = errors.New("storage failure")
+	if vs := Check(h.ops, State{"k": everywhere(Read{})}); len(vs) != 0 {
+		t.Fatalf("violations = %v, want none", vs)
+	}
+}
+
+func TestCheckResurrection(t *testing.T) {
+	h := newHistory().
+		put("a", "k", "v1").
+		put("b", "other", "x").
+		get("b", "k", "v1").
+		del("b", "k"). // b held v1: it is gone for good
+		get("c", "k")
+	final := State{
+		"k":     everywhere(found("v1")),
+		"other": everywhere(found("x")),
+	}
+
+	vs := Check(h.ops, final)
+	if len(vs) != 1 || vs[0].Invariant != "resurrection" {
+		t.Fatalf("violations = %v, want a resurrection", vs)
+	}
+	if got := indexes(vs[0].Ops); !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Fatalf("counterexample = %v, want [0 2 3]", got)
+	}
+}
+
+func TestCheckDeleteOfUnseenValue(t *testing.T) {
+	// c deleted without having seen v1: the put is concurrent and survives
+	h := newHistory().put("a", "k", "v1").del("c", "k")
+	if vs := Check(h.ops, State{"k": everywhere(found("v1"))}); len(vs) != 0 {
+		t.Fatalf("violations = %v, want none", vs)
+	}
+}
+
+func TestCheckPhantomRead(t *testing.T) {
+	h := newHistory().put("a", "k", "v1").get("b", "k", "v1", "v0")
+	vs := Check(h.ops, State{"k": everywhere(found("v1"))})
+	if len(vs) != 1 || vs[0].Invariant != "phantom read" {
+		t.Fatalf("violations = %v, want a phantom read", vs)
+	}
+	if got := indexes(vs[0].Ops); !reflect.DeepEqual(got, []int{1}) {
+		t.Fatalf("counterexample = %v, want [1]", got)
+	}
+}
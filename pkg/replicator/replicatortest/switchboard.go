@@ -0,0 +1,406 @@
+package replicatortest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+const (
+	// firstPort is the port of the first address handed out; nothing is
+	// bound to it, addresses only name transports.
+	firstPort = 17000
+
+	// packetBuffer is how many packets wait for a node before new ones are
+	// dropped, as a full socket buffer would.
+	packetBuffer = 1024
+)
+
+var (
+	// ErrUnreachable is returned when dialing a node the sender cannot reach.
+	ErrUnreachable = errors.New("replicatortest: node unreachable")
+
+	// ErrTransportShutdown is returned when using a transport shut down.
+	ErrTransportShutdown = errors.New("replicatortest: transport shut down")
+)
+
+// Rule is what happens to what a node sends to another one. The zero Rule
+// delivers everything at once.
+type Rule struct {
+	// Drop is the probability that a packet is lost, or that dialing fails.
+	Drop float64
+
+	// Delay holds every packet, and every stream being opened, that long.
+	Delay time.Duration
+
+	// Reorder holds every packet up to that long more, at random, so that
+	// later packets overtake earlier ones.
+	Reorder time.Duration
+
+	// Duplicate is the probability that a packet is delivered twice.
+	Duplicate float64
+}
+
+type link struct {
+	from, to string
+}
+
+// Switchboard is an in-memory network connecting memberlist transports by
+// node name. Packets and streams go through it without touching a socket,
+// and it applies per-link rules to them: partitions, loss, delays,
+// duplicates and reordering. Rules apply to what is sent from then on.
+//
+//	sb := replicatortest.NewSwitchboard(1)
+//	tr, _ := sb.Transport("node1")
+//	n := replicator.NewNode("node1", 1, "127.0.0.1", 0, 0, "", replicator.WithTransport(tr))
+//	sb.Drop("node1", "node2", 0.5)
+//
+// Streams are reliable and ordered, like TCP: only partitions, Drop and
+// Delay apply to them, when they are opened.
+type Switchboard struct {
+	mu        sync.Mutex
+	rand      *rand.Rand
+	addrs     map[string]string     // node name to address
+	endpoints map[string]*Transport // address to the transport using it
+	rules     map[link]Rule
+	cut       map[link]bool
+	down      map[string]bool
+	nextPort  int
+}
+
+// NewSwitchboard returns an empty switchboard. seed drives the random
+// faults, so that a run can be replayed.
+func NewSwitchboard(seed int64) *Switchboard {
+	return &Switchboard{
+		rand:      rand.New(rand.NewSource(seed)),
+		addrs:     make(map[string]string),
+		endpoints: make(map[string]*Transport),
+		rules:     make(map[link]Rule),
+		cut:       make(map[link]bool),
+		down:      make(map[string]bool),
+		nextPort:  firstPort,
+	}
+}
+
+// Transport connects node name to the switchboard. A node gets the same
+// address every time, so that it can restart once its previous transport
+// is shut down.
+func (s *Switchboard) Transport(name string) (*Transport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addr, ok := s.addrs[name]
+	if !ok {
+		addr = net.JoinHostPort("127.0.0.1", strconv.Itoa(s.nextPort))
+		s.nextPort++
+		s.addrs[name] = addr
+	}
+	if _, ok := s.endpoints[addr]; ok {
+		return nil, fmt.Errorf("replicatortest: %s is connected already", name)
+	}
+	host, port, _ := net.SplitHostPort(addr)
+	p, _ := strconv.Atoi(port)
+	t := &Transport{
+		sb:       s,
+		name:     name,
+		addr:     addr,
+		ip:       net.ParseIP(host),
+		port:     p,
+		packetCh: make(chan *memberlist.Packet, packetBuffer),
+		streamCh: make(chan net.Conn),
+		shutdown: make(chan struct{}),
+	}
+	s.endpoints[addr] = t
+	return t, nil
+}
+
+// Addr returns the gossip address of node name, to be used as a seed.
+// It is empty until the node got a transport.
+func (s *Switchboard) Addr(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addrs[name]
+}
+
+// SetRule replaces the rule of the link from one node to another.
+func (s *Switchboard) SetRule(from, to string, r Rule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r == (Rule{}) {
+		delete(s.rules, link{from, to})
+		return
+	}
+	s.rules[link{from, to}] = r
+}
+
+// Rule returns the rule of the link from one node to another.
+func (s *Switchboard) Rule(from, to string) Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rules[link{from, to}]
+}
+
+func (s *Switchboard) updateRule(from, to string, fn func(r *Rule)) {
+	r := s.Rule(from, to)
+	fn(&r)
+	s.SetRule(from, to, r)
+}
+
+// Drop loses what from sends to to with probability p.
+func (s *Switchboard) Drop(from, to string, p float64) {
+	s.updateRule(from, to, func(r *Rule) { r.Drop = p })
+}
+
+// Delay holds what from sends to to for d; zero removes the delay.
+func (s *Switchboard) Delay(from, to string, d time.Duration) {
+	s.updateRule(from, to, func(r *Rule) { r.Delay = d })
+}
+
+// Duplicate delivers what from sends to to twice with probability p.
+func (s *Switchboard) Duplicate(from, to string, p float64) {
+	s.updateRule(from, to, func(r *Rule) { r.Duplicate = p })
+}
+
+// Reorder holds every packet from sends to to up to window more, so that
+// they arrive out of order.
+func (s *Switchboard) Reorder(from, to string, window time.Duration) {
+	s.updateRule(from, to, func(r *Rule) { r.Reorder = window })
+}
+
+// Partition splits the nodes into groups that cannot reach each other;
+// nodes connected but left out of every group are isolated. It replaces
+// any previous partition.
+func (s *Switchboard) Partition(groups ...[]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group := make(map[string]int)
+	for g, members := range groups {
+		for _, name := range members {
+			group[name] = g + 1
+		}
+	}
+	s.cut = make(map[link]bool)
+	for a := range s.addrs {
+		for b := range s.addrs {
+			if a != b && (group[a] == 0 || group[a] != group[b]) {
+				s.cut[link{a, b}] = true
+			}
+		}
+	}
+}
+
+// Heal removes the partition and every rule.
+func (s *Switchboard) Heal() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cut = make(map[link]bool)
+	s.rules = make(map[link]Rule)
+}
+
+// setDown loses everything sent to and from node name while it is down,
+// whatever the partition.
+func (s *Switchboard) setDown(name string, down bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if down {
+		s.down[name] = true
+	} else {
+		delete(s.down, name)
+	}
+}
+
+// route returns the transport listening on addr and the rule to apply to
+// what from sends it, or nil if nothing gets there.
+func (s *Switchboard) route(from *Transport, addr string) (*Transport, Rule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	to, ok := s.endpoints[addr]
+	if !ok || s.down[from.name] || s.down[to.name] || s.cut[link{from.name, to.name}] {
+		return nil, Rule{}
+	}
+	return to, s.rules[link{from.name, to.name}]
+}
+
+// chance reports true with probability p.
+func (s *Switchboard) chance(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Float64() < p
+}
+
+// jitter returns a random duration below d.
+func (s *Switchboard) jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Duration(s.rand.Int63n(int64(d)))
+}
+
+// send delivers a packet from one transport to addr following the rules.
+func (s *Switchboard) send(from *Transport, addr string, b []byte) {
+	to, r := s.route(from, addr)
+	if to == nil || s.chance(r.Drop) {
+		return
+	}
+	copies := 1
+	if s.chance(r.Duplicate) {
+		copies++
+	}
+	for i := 0; i < copies; i++ {
+		p := &memberlist.Packet{
+			Buf:  append([]byte{}, b...),
+			From: &net.UDPAddr{IP: from.ip, Port: from.port},
+		}
+		delay := r.Delay + s.jitter(r.Reorder)
+		if delay == 0 {
+			to.receive(p)
+			continue
+		}
+		time.AfterFunc(delay, func() {
+			// the link may have been cut or the node crashed in the meantime
+			if to, _ := s.route(from, addr); to != nil {
+				to.receive(p)
+			}
+		})
+	}
+}
+
+// dial opens a stream from one transport to addr following the rules.
+func (s *Switchboard) dial(from *Transport, addr string, timeout time.Duration) (net.Conn, error) {
+	to, r := s.route(from, addr)
+	if to == nil || s.chance(r.Drop) {
+		return nil, ErrUnreachable
+	}
+	if r.Delay > 0 {
+		if r.Delay >= timeout {
+			time.Sleep(timeout)
+			return nil, ErrUnreachable
+		}
+		time.Sleep(r.Delay)
+		timeout -= r.Delay
+		if to, _ = s.route(from, addr); to == nil {
+			return nil, ErrUnreachable
+		}
+	}
+
+	local, remote := net.Pipe()
+	fromAddr := &net.TCPAddr{IP: from.ip, Port: from.port}
+	toAddr := &net.TCPAddr{IP: to.ip, Port: to.port}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case to.streamCh <- &pipeConn{Conn: remote, local: toAddr, remote: fromAddr}:
+		return &pipeConn{Conn: local, local: fromAddr, remote: toAddr}, nil
+	case <-to.shutdown:
+	case <-from.shutdown:
+	case <-timer.C:
+	}
+	local.Close()
+	remote.Close()
+	return nil, ErrUnreachable
+}
+
+// Transport is a memberlist.Transport connected to a Switchboard. Give it
+// to a node with replicator.WithTransport; the node shuts it down.
+type Transport struct {
+	sb   *Switchboard
+	name string
+	addr string
+	ip   net.IP
+	port int
+
+	packetCh chan *memberlist.Packet
+	streamCh chan net.Conn
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+var _ memberlist.Transport = (*Transport)(nil)
+
+// Addr returns the gossip address of the transport.
+func (t *Transport) Addr() string {
+	return t.addr
+}
+
+// FinalAdvertiseAddr implements memberlist.Transport. The address handed
+// out by the switchboard is advertised whatever the configuration says.
+func (t *Transport) FinalAdvertiseAddr(string, int) (net.IP, int, error) {
+	return t.ip, t.port, nil
+}
+
+// WriteTo implements memberlist.Transport. Lost packets are reported
+// sent, as UDP would.
+func (t *Transport) WriteTo(b []byte, addr string) (time.Time, error) {
+	select {
+	case <-t.shutdown:
+		return time.Time{}, ErrTransportShutdown
+	default:
+	}
+	t.sb.send(t, addr, b)
+	return time.Now(), nil
+}
+
+// PacketCh implements memberlist.Transport.
+func (t *Transport) PacketCh() <-chan *memberlist.Packet {
+	return t.packetCh
+}
+
+// DialTimeout implements memberlist.Transport.
+func (t *Transport) DialTimeout(addr string, timeout time.Duration) (net.Conn, error) {
+	select {
+	case <-t.shutdown:
+		return nil, ErrTransportShutdown
+	default:
+	}
+	return t.sb.dial(t, addr, timeout)
+}
+
+// StreamCh implements memberlist.Transport.
+func (t *Transport) StreamCh() <-chan net.Conn {
+	return t.streamCh
+}
+
+// Shutdown implements memberlist.Transport. It disconnects the transport
+// from the switchboard, freeing its address for the next transport of the
+// node.
+func (t *Transport) Shutdown() error {
+	t.shutdownOnce.Do(func() {
+		t.sb.mu.Lock()
+		if t.sb.endpoints[t.addr] == t {
+			delete(t.sb.endpoints, t.addr)
+		}
+		t.sb.mu.Unlock()
+		close(t.shutdown)
+	})
+	return nil
+}
+
+// receive queues a packet, or drops it if the node is not keeping up.
+func (t *Transport) receive(p *memberlist.Packet) {
+	p.Timestamp = time.Now()
+	select {
+	case t.packetCh <- p:
+	default:
+	}
+}
+
+// pipeConn is one end of an in-memory stream, with the addresses of the
+// transports it connects.
+type pipeConn struct {
+	net.Conn
+	local, remote net.Addr
+}
+
+func (c *pipeConn) LocalAddr() net.Addr  { return c.local }
+func (c *pipeConn) RemoteAddr() net.Addr { return c.remote }
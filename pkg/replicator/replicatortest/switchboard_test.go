@@ -0,0 +1,132 @@
+package replicatortest
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func connect(t *testing.T, sb *Switchboard, names ...string) map[string]*Transport {
+	t.Helper()
+	out := make(map[string]*Transport)
+	for _, name := range names {
+		tr, err := sb.Transport(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { tr.Shutdown() })
+		out[name] = tr
+	}
+	return out
+}
+
+// received drains the packets a transport got within wait.
+func received(tr *Transport, wait time.Duration) []string {
+	var got []string
+	timeout := time.After(wait)
+	for {
+		select {
+		case p := <-tr.PacketCh():
+			got = append(got, string(p.Buf))
+		case <-timeout:
+			return got
+		}
+	}
+}
+
+func TestSwitchboardPackets(t *testing.T) {
+	sb := NewSwitchboard(1)
+	trs := connect(t, sb, "a", "b", "c")
+	a, b, c := trs["a"], trs["b"], trs["c"]
+
+	a.WriteTo([]byte("hello"), b.Addr())
+	got := received(b, 50*time.Millisecond)
+	if len(got) != 1 || got[0] != "hello" {
+		t.Fatalf("b received %q", got)
+	}
+
+	sb.Partition([]string{"a"}, []string{"b", "c"})
+	a.WriteTo([]byte("cut"), b.Addr())
+	c.WriteTo([]byte("same side"), b.Addr())
+	if got := received(b, 50*time.Millisecond); len(got) != 1 || got[0] != "same side" {
+		t.Fatalf("b received %q across the partition", got)
+	}
+	if _, err := a.DialTimeout(b.Addr(), time.Second); err != ErrUnreachable {
+		t.Fatalf("dial across the partition: %v", err)
+	}
+	sb.Heal()
+
+	sb.Duplicate("a", "b", 1)
+	a.WriteTo([]byte("twice"), b.Addr())
+	if got := received(b, 50*time.Millisecond); len(got) != 2 {
+		t.Fatalf("b received %q, want a duplicate", got)
+	}
+
+	sb.SetRule("a", "b", Rule{Drop: 1})
+	a.WriteTo([]byte("lost"), b.Addr())
+	if got := received(b, 50*time.Millisecond); len(got) != 0 {
+		t.Fatalf("b received %q through a lossy link", got)
+	}
+
+	sb.SetRule("a", "b", Rule{Delay: 100 * time.Millisecond})
+	a.WriteTo([]byte("late"), b.Addr())
+	if got := received(b, 50*time.Millisecond); len(got) != 0 {
+		t.Fatalf("b received %q before the delay", got)
+	}
+	if got := received(b, 100*time.Millisecond); len(got) != 1 {
+		t.Fatalf("b received %q after the delay", got)
+	}
+}
+
+func TestSwitchboardReorder(t *testing.T) {
+	sb := NewSwitchboard(1)
+	trs := connect(t, sb, "a", "b")
+	a, b := trs["a"], trs["b"]
+
+	sb.Reorder("a", "b", 50*time.Millisecond)
+	for i := 0; i < 20; i++ {
+		a.WriteTo([]byte{byte('A' + i)}, b.Addr())
+	}
+	got := received(b, 100*time.Millisecond)
+	if len(got) != 20 {
+		t.Fatalf("b received %d packets, want 20", len(got))
+	}
+	for i := range got {
+		if got[i] != string(rune('A'+i)) {
+			return
+		}
+	}
+	t.Fatal("packets arrived in order")
+}
+
+func TestSwitchboardStreams(t *testing.T) {
+	sb := NewSwitchboard(1)
+	trs := connect(t, sb, "a", "b")
+	a, b := trs["a"], trs["b"]
+
+	go func() {
+		conn := <-b.StreamCh()
+		defer conn.Close()
+		conn.Write([]byte("from " + conn.RemoteAddr().String()))
+	}()
+	conn, err := a.DialTimeout(b.Addr(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	msg, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(msg) != "from "+a.Addr() {
+		t.Fatalf("read %q", msg)
+	}
+
+	b.Shutdown()
+	if _, err := a.DialTimeout(b.Addr(), time.Second); err != ErrUnreachable {
+		t.Fatalf("dial a node shut down: %v", err)
+	}
+	if _, err := sb.Transport("b"); err != nil {
+		t.Fatalf("restart b: %v", err)
+	}
+}
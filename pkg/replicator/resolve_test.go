@@ -0,0 +1,120 @@
+package replicator_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+// conflict writes two concurrent values of k across a partition and waits
+// until every node holds both.
+func conflict(t *testing.T, c *replicatortest.Cluster) *rpc.Event {
+	t.Helper()
+	c.Partition([]int{0}, []int{1, 2})
+	c.Put(0, "k", "a")
+	c.Put(1, "k", "b")
+	c.Heal()
+	c.WaitFor(valuesEverywhere(c, "k", "a", "b"))
+	ev, err := c.Get(2, "k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ev
+}
+
+// valuesEverywhere waits until every running node holds exactly want for id,
+// siblings included.
+func valuesEverywhere(c *replicatortest.Cluster, id string, want ...string) func() (bool, string) {
+	return func() (bool, string) {
+		for i := 0; i < c.Len(); i++ {
+			ev, err := c.Get(i, id)
+			if err != nil {
+				return false, fmt.Sprintf("%s: %v", c.Name(i), err)
+			}
+			if got := values(ev); !reflect.DeepEqual(got, want) {
+				return false, fmt.Sprintf("%s holds %q, want %q", c.Name(i), got, want)
+			}
+		}
+		return true, ""
+	}
+}
+
+// values returns the data of ev and its siblings, sorted.
+func values(ev *rpc.Event) []string {
+	out := []string{ev.Data}
+	for _, s := range ev.Siblings {
+		out = append(out, s.Data)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestResolveCollapsesSiblings(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+	ev := conflict(t, c)
+
+	resolved, err := c.Node(2).Resolve(context.Background(), &rpc.ResolveEventRequest{
+		Id:           "k",
+		Data:         "a+b",
+		SourceRegion: int32(c.Region(2)),
+		Context:      ev.Context,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Data != "a+b" || len(resolved.Siblings) != 0 {
+		t.Fatalf("resolved into %q, want a+b alone", values(resolved))
+	}
+	c.WaitFor(valuesEverywhere(c, "k", "a+b"))
+}
+
+func TestResolveKeepsConcurrentWrites(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 3, KeepEvents: true})
+	ev := conflict(t, c)
+
+	// a write the resolving client has not seen
+	c.Partition([]int{0}, []int{1, 2})
+	c.Put(1, "k", "c")
+	_, err := c.Node(0).Resolve(context.Background(), &rpc.ResolveEventRequest{
+		Id:           "k",
+		Data:         "a+b",
+		SourceRegion: int32(c.Region(0)),
+		Context:      ev.Context,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Heal()
+	c.WaitFor(valuesEverywhere(c, "k", "a+b", "c"))
+}
+
+func TestResolveArguments(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 1})
+	ctx := []*rpc.VectorEntry{{Region: 1, Counter: 1}}
+	for _, tt := range []struct {
+		name     string
+		req      *rpc.ResolveEventRequest
+		argument string
+	}{
+		{"no id", &rpc.ResolveEventRequest{Data: "v", Context: ctx}, "id"},
+		{"reserved id", &rpc.ResolveEventRequest{Id: "\x00regions", Data: "v", Context: ctx}, "id"},
+		{"no context", &rpc.ResolveEventRequest{Id: "k", Data: "v"}, "context"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Node(0).Resolve(context.Background(), tt.req)
+			twerr, ok := err.(twirp.Error)
+			if !ok || twerr.Code() != twirp.InvalidArgument || twerr.Meta("argument") != tt.argument {
+				t.Fatalf("Resolve() = %v, want an invalid %s argument", err, tt.argument)
+			}
+		})
+	}
+	if _, err := c.Get(0, "k"); err == nil {
+		t.Error("a rejected resolve stored the event")
+	}
+}
@@ -0,0 +1,98 @@
+package replicator
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/storage"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	defaultStuckAfter   = 5 * time.Minute
+	defaultMaxStuckKeys = 100
+)
+
+// GetReplicationStatus aggregates the commit progress of every stored event:
+// how many each region has yet to commit, how old the oldest of them is, and
+// which events have been pending for too long.
+func (n *Node) GetReplicationStatus(ctx context.Context, req *rpc.GetReplicationStatusRequest) (*rpc.ReplicationStatus, error) {
+	if req.StuckAfterSeconds < 0 {
+		return nil, twirp.InvalidArgumentError("stuck_after_seconds", "must not be negative")
+	}
+	if req.MaxStuckKeys < 0 {
+		return nil, twirp.InvalidArgumentError("max_stuck_keys", "must not be negative")
+	}
+	stuckAfter := time.Duration(req.StuckAfterSeconds) * time.Second
+	if stuckAfter == 0 {
+		stuckAfter = defaultStuckAfter
+	}
+	maxStuck := int(req.MaxStuckKeys)
+	if maxStuck == 0 {
+		maxStuck = defaultMaxStuckKeys
+	}
+
+	now := time.Now()
+	age := func(p storage.PendingEvent) time.Duration {
+		if p.WrittenAt.IsZero() {
+			return 0
+		}
+		return now.Sub(p.WrittenAt)
+	}
+
+	live := n.delegate.Regions()
+	status := &rpc.ReplicationStatus{
+		RegionId:        int32(n.regionID),
+		NumberOfRegions: int32(len(live)),
+	}
+	regions := make(map[uint]*rpc.RegionStatus, len(live))
+	for _, region := range live {
+		regions[region] = &rpc.RegionStatus{Region: int32(region)}
+		status.Regions = append(status.Regions, regions[region])
+	}
+	var stuck []storage.PendingEvent
+	total, err := n.delegate.Pending(func(p storage.PendingEvent) {
+		a := age(p)
+		status.PendingEvents++
+		if seconds := int64(a / time.Second); seconds > status.OldestPendingAgeSeconds {
+			status.OldestPendingAgeSeconds = seconds
+		}
+		for _, region := range p.Missing {
+			r, ok := regions[region]
+			if !ok {
+				// the regions changed meanwhile
+				continue
+			}
+			r.PendingEvents++
+			if seconds := int64(a / time.Second); seconds > r.OldestPendingAgeSeconds {
+				r.OldestPendingAgeSeconds = seconds
+			}
+		}
+		if a >= stuckAfter {
+			stuck = append(stuck, p)
+		}
+	})
+	if err != nil {
+		n.logger.Error("failed to read replication status", "error", err)
+		return nil, err
+	}
+	status.TotalEvents = int64(total)
+
+	status.StuckEvents = int64(len(stuck))
+	sort.Slice(stuck, func(i, j int) bool {
+		return stuck[i].WrittenAt.Before(stuck[j].WrittenAt)
+	})
+	if len(stuck) > maxStuck {
+		stuck = stuck[:maxStuck]
+	}
+	for _, p := range stuck {
+		e := &rpc.StuckEvent{Id: p.Key, AgeSeconds: int64(age(p) / time.Second)}
+		for _, region := range p.Missing {
+			e.MissingRegions = append(e.MissingRegions, int32(region))
+		}
+		status.Stuck = append(status.Stuck, e)
+	}
+	return status, nil
+}
@@ -0,0 +1,103 @@
+package replicator_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/replicator/replicatortest"
+	"github.com/kyawmyintthein/gossip-replicator/rpc"
+	"github.com/twitchtv/twirp"
+)
+
+// pendingByRegion returns the events each region has yet to commit.
+func pendingByRegion(status *rpc.ReplicationStatus) map[int32]int64 {
+	out := make(map[int32]int64)
+	for _, r := range status.Regions {
+		out[r.Region] = r.PendingEvents
+	}
+	return out
+}
+
+func TestReplicationStatus(t *testing.T) {
+	// region 3 never joins, nothing is committed everywhere
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 2, NumberOfRegions: 3})
+	ctx := context.Background()
+	status := func(req *rpc.GetReplicationStatusRequest) *rpc.ReplicationStatus {
+		t.Helper()
+		s, err := c.Node(0).GetReplicationStatus(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	for i := 0; i < 5; i++ {
+		c.Put(0, fmt.Sprint("replicated-", i), "v")
+	}
+	c.WaitFor(func() (bool, string) {
+		got := pendingByRegion(status(&rpc.GetReplicationStatusRequest{}))
+		return reflect.DeepEqual(got, map[int32]int64{1: 0, 2: 0, 3: 5}),
+			fmt.Sprintf("pending %v, want region 2 to commit every event", got)
+	})
+
+	c.Partition([]int{0}, []int{1})
+	c.Put(0, "cut-off-0", "v")
+	c.Put(0, "cut-off-1", "v")
+	s := status(&rpc.GetReplicationStatusRequest{})
+	if s.RegionId != 1 || s.NumberOfRegions != 3 || s.TotalEvents != 7 || s.PendingEvents != 7 {
+		t.Errorf("region %d of %d, %d events, %d pending, want region 1 of 3 and 7 events all pending",
+			s.RegionId, s.NumberOfRegions, s.TotalEvents, s.PendingEvents)
+	}
+	// committed by a region are the events it is not missing
+	if got, want := pendingByRegion(s), map[int32]int64{1: 0, 2: 2, 3: 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pending %v, want %v", got, want)
+	}
+	if len(s.Stuck) != 0 {
+		t.Errorf("%d events stuck right after being written", len(s.Stuck))
+	}
+
+	// the oldest stuck events first, up to max_stuck_keys
+	time.Sleep(1100 * time.Millisecond)
+	s = status(&rpc.GetReplicationStatusRequest{StuckAfterSeconds: 1, MaxStuckKeys: 3})
+	if s.StuckEvents != 7 || len(s.Stuck) != 3 {
+		t.Fatalf("%d stuck events, %d listed, want 7 with 3 listed", s.StuckEvents, len(s.Stuck))
+	}
+	for i, e := range s.Stuck {
+		if e.Id != fmt.Sprint("replicated-", i) || !reflect.DeepEqual(e.MissingRegions, []int32{3}) || e.AgeSeconds < 1 {
+			t.Errorf("stuck event %d is %+v, want replicated-%d missing region 3", i, e, i)
+		}
+	}
+	s = status(&rpc.GetReplicationStatusRequest{StuckAfterSeconds: 1})
+	if len(s.Stuck) != 7 {
+		t.Fatalf("%d stuck events listed by default, want all 7", len(s.Stuck))
+	}
+	if last := s.Stuck[6]; last.Id != "cut-off-1" || !reflect.DeepEqual(last.MissingRegions, []int32{2, 3}) {
+		t.Errorf("newest stuck event is %+v, want cut-off-1 missing regions 2 and 3", last)
+	}
+	if s.OldestPendingAgeSeconds < 1 {
+		t.Errorf("oldest pending event %ds old, want at least 1s", s.OldestPendingAgeSeconds)
+	}
+}
+
+func TestReplicationStatusArguments(t *testing.T) {
+	c := replicatortest.New(t, replicatortest.Config{Nodes: 1})
+	for _, tt := range []struct {
+		name     string
+		req      *rpc.GetReplicationStatusRequest
+		argument string
+	}{
+		{"negative stuck after", &rpc.GetReplicationStatusRequest{StuckAfterSeconds: -1}, "stuck_after_seconds"},
+		{"negative max stuck keys", &rpc.GetReplicationStatusRequest{MaxStuckKeys: -1}, "max_stuck_keys"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Node(0).GetReplicationStatus(context.Background(), tt.req)
+			twerr, ok := err.(twirp.Error)
+			if !ok || twerr.Code() != twirp.InvalidArgument || twerr.Meta("argument") != tt.argument {
+				t.Fatalf("GetReplicationStatus() = %v, want an invalid %s argument", err, tt.argument)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+)
+
+const (
+	defaultGCInterval     = 5 * time.Minute
+	defaultGCDiscardRatio = 0.5
+)
+
+// Options configures a BadgerStorage.
+type Options struct {
+	// Dir is the directory holding the database files. It is ignored
+	// when InMemory is set.
+	Dir string
+
+	// InMemory keeps everything in memory; the state is lost on restart.
+	InMemory bool
+
+	// SyncWrites fsyncs every write before acknowledging it.
+	SyncWrites bool
+
+	// GCInterval is how often the value log is garbage collected.
+	// Defaults to 5 minutes; a negative value disables value log GC.
+	GCInterval time.Duration
+
+	// GCDiscardRatio is the fraction of stale data a value log file needs
+	// before it is rewritten. Defaults to 0.5.
+	GCDiscardRatio float64
+
+	// Logger receives the storage and badger logs; info and above go to
+	// stderr by default. Badger's own info messages are logged at debug.
+	Logger logging.Logger
+}
+
+// BadgerStorage is a Backend driver on top of badger, either in memory or on disk.
+type BadgerStorage struct {
+	db     *badger.DB
+	logger logging.Logger
+
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+var _ Backend = (*BadgerStorage)(nil)
+
+// NewInMemoryDB opens a badger database living only in memory.
+func NewInMemoryDB() (*BadgerStorage, error) {
+	return NewBadgerDB(Options{InMemory: true})
+}
+
+// NewBadgerDB opens a badger database with the given options. Opening an
+// existing directory reloads every event persisted by a previous run.
+func NewBadgerDB(o Options) (*BadgerStorage, error) {
+	if !o.InMemory && o.Dir == "" {
+		return nil, errors.New("storage: data directory is required for a persistent database")
+	}
+	if o.GCInterval == 0 {
+		o.GCInterval = defaultGCInterval
+	}
+	if o.GCDiscardRatio <= 0 || o.GCDiscardRatio >= 1 {
+		o.GCDiscardRatio = defaultGCDiscardRatio
+	}
+	if o.Logger == nil {
+		o.Logger = logging.Default()
+	}
+
+	opts := badger.DefaultOptions(o.Dir)
+	if o.InMemory {
+		opts = badger.DefaultOptions("").WithInMemory(true)
+	}
+	opts = opts.WithSyncWrites(o.SyncWrites).WithLogger(badgerLogger{o.Logger})
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &BadgerStorage{
+		db:     db,
+		logger: o.Logger,
+		stop:   make(chan struct{}),
+	}
+	// the value log only exists on disk
+	if !o.InMemory && o.GCInterval > 0 {
+		s.wg.Add(1)
+		go s.runValueLogGC(o.GCInterval, o.GCDiscardRatio)
+	}
+	return s, nil
+}
+
+// runValueLogGC periodically reclaims space from stale value log entries.
+func (c *BadgerStorage) runValueLogGC(interval time.Duration, discardRatio float64) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			// one call rewrites at most one file; keep going while it finds work
+			for {
+				err := c.db.RunValueLogGC(discardRatio)
+				if err != nil {
+					if err != badger.ErrNoRewrite && err != badger.ErrRejected {
+						c.logger.Error("value log gc failed", "error", err)
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
+// Put adds config property to config store
+func (c *BadgerStorage) Put(key string, value []byte) error {
+	err := c.db.Update(func(txn *badger.Txn) error {
+		err := txn.Set([]byte(key), value)
+		return err
+	})
+	return err
+}
+
+// Get returns a property value
+func (c *BadgerStorage) Get(key string) ([]byte, error) {
+	var data []byte
+	err := c.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				return ErrKeyNotFound
+			}
+			return err
+		}
+
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Del removes a property value
+func (c *BadgerStorage) Del(key string) error {
+	err := c.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete([]byte(key))
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Iterate walks every property whose key starts with prefix
+func (c *BadgerStorage) Iterate(prefix string, fn func(key string, value []byte) error) error {
+	return c.Scan(prefix, "", fn)
+}
+
+// Scan walks every property whose key starts with prefix from start onwards
+func (c *BadgerStorage) Scan(prefix, start string, fn func(key string, value []byte) error) error {
+	err := c.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		seek := prefix
+		if start > seek {
+			seek = start
+		}
+		for it.Seek([]byte(seek)); it.Valid(); it.Next() {
+			item := it.Item()
+			err := item.Value(func(val []byte) error {
+				return fn(string(item.Key()), val)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == ErrStopIteration {
+		return nil
+	}
+	return err
+}
+
+// Snapshot copies every property out of the store
+func (c *BadgerStorage) Snapshot() (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	err := c.Iterate("", func(key string, value []byte) error {
+		data[key] = append([]byte{}, value...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Close stops the value log GC and flushes the database to disk
+func (c *BadgerStorage) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.stop)
+		c.wg.Wait()
+		err = c.db.Close()
+	})
+	return err
+}
+
+// badgerLogger routes badger's printf style logs to a Logger. Badger is
+// chatty at info level, so those messages are logged at debug.
+type badgerLogger struct {
+	logger logging.Logger
+}
+
+func (l badgerLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Error(badgerMessage(format, args), "component", "badger")
+}
+
+func (l badgerLogger) Warningf(format string, args ...interface{}) {
+	l.logger.Warn(badgerMessage(format, args), "component", "badger")
+}
+
+func (l badgerLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debug(badgerMessage(format, args), "component", "badger")
+}
+
+func (l badgerLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Debug(badgerMessage(format, args), "component", "badger")
+}
+
+func badgerMessage(format string, args []interface{}) string {
+	return strings.TrimSpace(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+)
+
+// openDelegate opens the badger database in dir for a delegate of region 1.
+func openDelegate(t *testing.T, dir string) (*Delegate, *BadgerStorage) {
+	t.Helper()
+	db, err := NewBadgerDB(Options{Dir: dir, GCInterval: -1, Logger: logging.Nop()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDelegate(db, nodemeta.Meta{Region: 1}, 1, threeRegions)
+	d.SetLogger(logging.Nop())
+	return d, db
+}
+
+func TestBadgerReopen(t *testing.T) {
+	dir := t.TempDir()
+	d, db := openDelegate(t, dir)
+
+	if _, err := d.Write(V{ID: "local", Data: []byte("written here")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	src := newTestDelegate(2)
+	if _, err := src.Write(V{ID: "remote", Data: []byte("written in region 2")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := src.backend.Get("remote")
+	d.NotifyMsg(append([]byte{msgPut}, b...))
+	// stored by a node that crashed before recording its own commit, with
+	// a clock slightly ahead
+	uncommitted, err := src.Write(V{ID: "uncommitted", Data: []byte("v")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncommitted.Meta.HLC.WallTime += (400 * time.Millisecond).Nanoseconds()
+	b, _ = json.Marshal(uncommitted)
+	if err := db.Put("uncommitted", b); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, db = openDelegate(t, dir)
+	total, pending, err := d.Recover()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || pending != 3 {
+		t.Errorf("recovered %d events, %d pending, want 3 pending", total, pending)
+	}
+	for _, tt := range []struct {
+		key       string
+		data      string
+		committed CommitSet
+	}{
+		{"local", "written here", CommitSet{}.Add(1)},
+		{"remote", "written in region 2", CommitSet{}.Add(1).Add(2)},
+		{"uncommitted", "v", CommitSet{}.Add(1).Add(2)},
+	} {
+		v, err := d.load(tt.key)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.key, err)
+		}
+		if string(v.Data) != tt.data || !reflect.DeepEqual(v.Meta.CommitedRegions, tt.committed) {
+			t.Errorf("%s: reloaded %q committed by %v, want %q committed by %v",
+				tt.key, v.Data, v.Meta.CommitedRegions, tt.data, tt.committed)
+		}
+	}
+
+	// new writes are stamped after every event of the previous run
+	again, err := d.Write(V{ID: "local", Data: []byte("rewritten")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Meta.HLC.Compare(uncommitted.Meta.HLC) <= 0 {
+		t.Errorf("rewrite stamped %v, not after %v", again.Meta.HLC, uncommitted.Meta.HLC)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the commit recorded on recovery was written back
+	_, db = openDelegate(t, dir)
+	defer db.Close()
+	b, err = db.Get("uncommitted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v V
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Meta.CommitedRegions[1] {
+		t.Errorf("commit of region 1 lost on restart: %v", v.Meta.CommitedRegions)
+	}
+}
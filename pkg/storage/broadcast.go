@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+const (
+	// maxBroadcastSize is the largest value gossiped as a broadcast. It
+	// keeps messages within a single UDP packet; larger values are left
+	// to the next push/pull.
+	maxBroadcastSize = 1024
+
+	// retransmitMult scales how many times a broadcast is retransmitted
+	// relative to the log of the cluster size.
+	retransmitMult = 4
+)
+
+// putBroadcast gossips a single written value.
+type putBroadcast struct {
+	key string
+	msg []byte
+}
+
+var _ memberlist.NamedBroadcast = (*putBroadcast)(nil)
+
+// Invalidates checks if enqueuing the current broadcast
+// invalidates a previous broadcast
+func (b *putBroadcast) Invalidates(other memberlist.Broadcast) bool {
+	o, ok := other.(*putBroadcast)
+	return ok && o.key == b.key
+}
+
+// Name is used to invalidate older broadcasts of the same key in O(1)
+func (b *putBroadcast) Name() string {
+	return b.key
+}
+
+// Message returns a byte form of the message
+func (b *putBroadcast) Message() []byte {
+	return b.msg
+}
+
+// Finished is invoked when the message will no longer
+// be broadcast, either due to invalidation or to the
+// transmit limit being reached
+func (b *putBroadcast) Finished() {}
+
+// broadcast queues value for gossip unless it is too large to fit in a packet.
+func (d *Delegate) broadcast(key string, value []byte) {
+	_, broadcasts := d.cluster()
+	if broadcasts == nil || len(value)+1 > maxBroadcastSize {
+		return
+	}
+	msg := make([]byte, 0, len(value)+1)
+	msg = append(msg, msgPut)
+	msg = append(msg, value...)
+	broadcasts.QueueBroadcast(&putBroadcast{key: key, msg: msg})
+}
+
+// handlePut merges a value received through a broadcast.
+func (d *Delegate) handlePut(buf []byte) {
+	var v V
+	err := json.Unmarshal(buf, &v)
+	if err != nil {
+		d.reject(PayloadBroadcast, "", buf, err)
+		return
+	}
+
+	start := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+	if _, ok := d.stale(v.ID, buf); ok {
+		// a retransmission of an event every region committed since
+		d.observer.Merged(SourceBroadcast, 1, 0, time.Since(start))
+		return
+	}
+	var applied int
+	if d.mergeEntry(v.ID, buf) {
+		applied = 1
+	}
+	d.observer.Merged(SourceBroadcast, 1, applied, time.Since(start))
+	d.logger.Debug("merged broadcast", "key", v.ID, "version", v.Meta.Version)
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"encoding/json"
+)
+
+// CommitSet records the regions that committed a version of an event. It
+// is a grow-only set: copies of the same version are merged by union,
+// whichever copy wins, so acknowledgements are never lost.
+type CommitSet map[uint]bool
+
+// Add returns a copy of s including region.
+func (s CommitSet) Add(region uint) CommitSet {
+	return s.Merge(CommitSet{region: true})
+}
+
+// Merge returns the union of s and o.
+func (s CommitSet) Merge(o CommitSet) CommitSet {
+	out := make(CommitSet, len(s)+len(o))
+	for region, ok := range s {
+		if ok {
+			out[region] = true
+		}
+	}
+	for region, ok := range o {
+		if ok {
+			out[region] = true
+		}
+	}
+	return out
+}
+
+// ReevaluateCommits checks again which stored events every region has
+// committed and marks them for deletion, as merging a remote copy would.
+// It is meant to run when the topology of the cluster changes. It returns
+// how many events were marked.
+func (d *Delegate) ReevaluateCommits() (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	regions := d.Regions()
+	completed := make(map[string][]byte)
+	err := d.iterate(func(key string, val []byte) error {
+		var v V
+		if err := json.Unmarshal(val, &v); err != nil {
+			return nil
+		}
+		// tombstones outlive their commit for a grace period, see CollectTombstones,
+		// and conflicts stay until the application resolves them
+		if v.Meta.ToDelete || v.Meta.Tombstone || len(v.Siblings) > 0 ||
+			!complete(v.Meta, regions) {
+			return nil
+		}
+		v.Meta.ToDelete = true
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		completed[key] = b
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for key, b := range completed {
+		err = d.backend.Put(key, b)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(completed) > 0 {
+		d.logger.Info("events committed by every region", "events", len(completed))
+		// peers marking them too hold the same copies, which anti-entropy
+		// never exchanges, so no side would be done with them
+		d.enqueue(func() error { return d.pushDone(completed) })
+	}
+	return len(completed), nil
+}
@@ -0,0 +1,317 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+)
+
+// cluster is one delegate per region, exchanging values by hand.
+type cluster map[uint]*Delegate
+
+func newCluster() cluster {
+	c := make(cluster)
+	for _, region := range threeRegions {
+		d := NewDelegate(memBackend{}, nodemeta.Meta{Region: region}, region, threeRegions)
+		d.SetLogger(logging.Nop())
+		c[region] = d
+	}
+	return c
+}
+
+// send merges the value stored by region from into region to, as a
+// push/pull or broadcast would. It reports false if from holds nothing.
+func (c cluster) send(t *testing.T, from, to uint, key string) bool {
+	t.Helper()
+	b, err := c[from].backend.Get(key)
+	if err == ErrKeyNotFound {
+		return false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	c[to].mergeEntry(key, b)
+	return true
+}
+
+// load returns the value region stores under key, or false once deleted.
+func (c cluster) load(t *testing.T, region uint, key string) (V, bool) {
+	t.Helper()
+	v, err := c[region].load(key)
+	if err == ErrKeyNotFound {
+		return V{}, false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v, true
+}
+
+// edges are the directed links between the three regions.
+var edges = [][2]uint{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}
+
+// permutations returns every ordering of edges.
+func permutations(edges [][2]uint) [][][2]uint {
+	if len(edges) <= 1 {
+		return [][][2]uint{edges}
+	}
+	var out [][][2]uint
+	for i := range edges {
+		rest := make([][2]uint, 0, len(edges)-1)
+		rest = append(rest, edges[:i]...)
+		rest = append(rest, edges[i+1:]...)
+		for _, p := range permutations(rest) {
+			out = append(out, append([][2]uint{edges[i]}, p...))
+		}
+	}
+	return out
+}
+
+func regionsOf(s CommitSet) []uint {
+	var regions []uint
+	for region, ok := range s {
+		if ok {
+			regions = append(regions, region)
+		}
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i] < regions[j] })
+	return regions
+}
+
+func TestCommitSetMerge(t *testing.T) {
+	a := CommitSet{1: true}
+	b := CommitSet{2: true, 3: true}
+
+	ab, ba := a.Merge(b), b.Merge(a)
+	if !reflect.DeepEqual(ab, ba) {
+		t.Fatalf("merge is not commutative: %v != %v", ab, ba)
+	}
+	if got := regionsOf(ab); !reflect.DeepEqual(got, threeRegions) {
+		t.Fatalf("merge = %v, want %v", got, threeRegions)
+	}
+	if !reflect.DeepEqual(ab.Merge(ab), ab) {
+		t.Fatal("merge is not idempotent")
+	}
+	if got := regionsOf(CommitSet(nil).Add(2)); !reflect.DeepEqual(got, []uint{2}) {
+		t.Fatalf("nil.Add(2) = %v", got)
+	}
+	if len(a) != 1 || len(b) != 2 {
+		t.Fatal("merge modified its operands")
+	}
+}
+
+// TestMergeKeepsLocalCommits is the regression: a copy of the same version
+// committed by fewer regions used to replace the local one.
+func TestMergeKeepsLocalCommits(t *testing.T) {
+	c := newCluster()
+	_, err := c[1].Write(V{ID: "k", Data: []byte("v1")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.send(t, 1, 2, "k") // 2 has {1,2}
+	c.send(t, 1, 3, "k") // 3 has {1,3}
+	c.send(t, 2, 1, "k") // 1 has {1,2}
+	c.send(t, 3, 1, "k") // the copy of 3 does not know about 2
+
+	v, ok := c.load(t, 1, "k")
+	if !ok {
+		t.Fatal("event deleted")
+	}
+	if got := regionsOf(v.Meta.CommitedRegions); !reflect.DeepEqual(got, threeRegions) {
+		t.Fatalf("commits = %v, want %v", got, threeRegions)
+	}
+	if !v.Meta.ToDelete {
+		t.Fatal("event committed by every region is not marked for deletion")
+	}
+}
+
+// TestMergeInterleavings delivers a write from region 1 along the links
+// between three regions in every order, then lets anti-entropy finish.
+// Commit sets must only grow, an event must not be deleted before every
+// region stored it, and every region must end up deleting it.
+func TestMergeInterleavings(t *testing.T) {
+	for _, order := range permutations(edges) {
+		order := order
+		t.Run(fmt.Sprint(order), func(t *testing.T) {
+			c := newCluster()
+			_, err := c[1].Write(V{ID: "k", Data: []byte("v1")}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored := map[uint]bool{1: true}
+			seen := map[uint][]uint{1: {1}}
+
+			check := func(step string) {
+				for _, region := range threeRegions {
+					v, ok := c.load(t, region, "k")
+					if !ok {
+						if stored[region] && len(stored) != len(threeRegions) {
+							t.Fatalf("%s: region %d deleted the event before every region stored it", step, region)
+						}
+						continue
+					}
+					stored[region] = true
+					got := regionsOf(v.Meta.CommitedRegions)
+					for _, r := range seen[region] {
+						if !v.Meta.CommitedRegions[r] {
+							t.Fatalf("%s: region %d lost the commit of %d: %v, was %v", step, region, r, got, seen[region])
+						}
+					}
+					seen[region] = got
+					if v.Meta.ToDelete && len(stored) != len(threeRegions) {
+						t.Fatalf("%s: region %d marked the event for deletion with commits %v", step, region, got)
+					}
+				}
+			}
+
+			for _, e := range order {
+				c.send(t, e[0], e[1], "k")
+				check(fmt.Sprintf("%d->%d", e[0], e[1]))
+			}
+			for round := 0; round < 2; round++ {
+				for _, e := range edges {
+					c.send(t, e[0], e[1], "k")
+					check(fmt.Sprintf("anti-entropy %d->%d", e[0], e[1]))
+				}
+			}
+
+			for _, region := range threeRegions {
+				if v, ok := c.load(t, region, "k"); ok && !v.Meta.ToDelete {
+					t.Errorf("region %d still waits for commits: %v", region, regionsOf(v.Meta.CommitedRegions))
+				}
+			}
+		})
+	}
+}
+
+// TestMergeConcurrentWrites has every region write the same event before
+// hearing from the others. Whatever the order of delivery, the regions
+// converge to the same versions, each committed by every region.
+func TestMergeConcurrentWrites(t *testing.T) {
+	for _, order := range permutations(edges) {
+		order := order
+		t.Run(fmt.Sprint(order), func(t *testing.T) {
+			c := newCluster()
+			for _, region := range threeRegions {
+				_, err := c[region].Write(V{ID: "k", Data: []byte(fmt.Sprint("from ", region))}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, e := range order {
+				c.send(t, e[0], e[1], "k")
+			}
+			for _, e := range edges {
+				c.send(t, e[0], e[1], "k")
+			}
+
+			var first []byte
+			for _, region := range threeRegions {
+				v, ok := c.load(t, region, "k")
+				if !ok {
+					t.Fatalf("region %d deleted an event with unresolved siblings", region)
+				}
+				if len(v.Siblings) != len(threeRegions)-1 {
+					t.Fatalf("region %d holds %d siblings, want %d", region, len(v.Siblings), len(threeRegions)-1)
+				}
+				for _, version := range v.versions() {
+					if got := regionsOf(version.Meta.CommitedRegions); !reflect.DeepEqual(got, threeRegions) {
+						t.Fatalf("region %d: version from %d committed by %v", region, version.Meta.HLC.Region, got)
+					}
+				}
+				b, err := json.Marshal(v)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if first == nil {
+					first = b
+				} else if string(b) != string(first) {
+					t.Fatalf("region %d diverged:\n%s\n%s", region, b, first)
+				}
+			}
+		})
+	}
+}
+
+// TestMergeNewVersionRestartsCommits checks that commits are tracked per
+// version: a later write must be committed again by every region.
+func TestMergeNewVersionRestartsCommits(t *testing.T) {
+	c := newCluster()
+	_, err := c[1].Write(V{ID: "k", Data: []byte("v1")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.send(t, 1, 2, "k")
+	c.send(t, 2, 3, "k") // 3 has {1,2,3}
+
+	_, err = c[2].Write(V{ID: "k", Data: []byte("v2")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.send(t, 3, 2, "k") // the older version does not bring its commits along
+
+	v, ok := c.load(t, 2, "k")
+	if !ok {
+		t.Fatal("event deleted")
+	}
+	if string(v.Data) != "v2" {
+		t.Fatalf("data = %q, want v2", v.Data)
+	}
+	if got := regionsOf(v.Meta.CommitedRegions); !reflect.DeepEqual(got, []uint{2}) {
+		t.Fatalf("commits = %v, want [2]", got)
+	}
+	if v.Meta.ToDelete {
+		t.Fatal("new version marked for deletion")
+	}
+}
+
+func TestReevaluateCommits(t *testing.T) {
+	d := newTestDelegate(1)
+	committed := CommitSet{}.Add(1).Add(2)
+	for _, v := range []V{
+		{ID: "done", Meta: Meta{CommitedRegions: committed}},
+		{ID: "pending", Meta: Meta{CommitedRegions: CommitSet{}.Add(1)}},
+		// kept for their grace period, see CollectTombstones
+		{ID: "tombstone", Meta: Meta{CommitedRegions: committed, Tombstone: true}},
+		// kept until resolved
+		{ID: "conflict", Meta: Meta{CommitedRegions: committed}, Siblings: []V{{ID: "conflict"}}},
+	} {
+		b, _ := json.Marshal(v)
+		if err := d.backend.Put(v.ID, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	marked := func() []string {
+		var keys []string
+		for _, key := range []string{"done", "pending", "tombstone", "conflict"} {
+			v, err := d.load(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.Meta.ToDelete {
+				keys = append(keys, key)
+			}
+		}
+		return keys
+	}
+
+	if n, err := d.ReevaluateCommits(); err != nil || n != 0 {
+		t.Fatalf("ReevaluateCommits() = %d, %v while region 3 is waited for, want nothing marked", n, err)
+	}
+	// region 3 is retired
+	d.SetRegions([]uint{1, 2})
+	if n, err := d.ReevaluateCommits(); err != nil || n != 1 {
+		t.Fatalf("ReevaluateCommits() = %d, %v, want 1 event marked", n, err)
+	}
+	if got := marked(); !reflect.DeepEqual(got, []string{"done"}) {
+		t.Fatalf("marked %v, want only done", got)
+	}
+	if n, _ := d.ReevaluateCommits(); n != 0 {
+		t.Errorf("marked %d events again", n)
+	}
+}
@@ -0,0 +1,527 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/hlc"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+)
+
+// Delegate gossips the content of a Backend through memberlist.
+type Delegate struct {
+	mu sync.Mutex
+
+	regionID uint
+
+	// regions that must commit an event before it is deleted
+	regions regionSet
+
+	// shared with other nodes through memberlist
+	metadata nodemeta.Meta
+
+	// node internal state - this is the actual config being gossiped
+	backend Backend
+
+	// cluster used to exchange deltas with peers, set once memberlist is
+	// created, when it may already be calling the delegate; see cluster
+	clusterMu  sync.RWMutex
+	memberlist gossip
+
+	// members messages are sent to, see PeerAlive
+	peers peers
+
+	// work needing a network round trip, run by the reply workers; see enqueue
+	replies  chan func() error
+	stopped  chan struct{}
+	stopOnce sync.Once
+	workers  sync.WaitGroup
+
+	// set by Stop, once merges no longer touch the backend
+	closed bool
+
+	// writes waiting to be gossiped to peers
+	broadcasts *memberlist.TransmitLimitedQueue
+
+	// stamps local writes and follows the timestamps of remote ones
+	clock *hlc.Clock
+
+	// how long tombstones acknowledged by every region are kept
+	tombstoneGrace time.Duration
+
+	// events deleted once every region committed them, see forget
+	forgotten       map[string]forgottenEvent
+	forgottenPruned time.Time
+
+	// payloads from peers that could not be decoded or applied
+	quarantine quarantine
+
+	// entries from peers stamped ahead of the local clock, merged later
+	skewed skewed
+
+	// told about merges and garbage collection, e.g. to export metrics
+	observer Observer
+
+	logger logging.Logger
+}
+
+var _ memberlist.Delegate = (*Delegate)(nil)
+
+// NewDelegate returns a Delegate storing events in backend for the given
+// region. An event is complete once the given regions committed it; see
+// SetRegions to follow changes of the topology.
+func NewDelegate(backend Backend, md nodemeta.Meta, regionID uint, regions []uint) *Delegate {
+	d := &Delegate{
+		metadata:       md,
+		regionID:       regionID,
+		backend:        backend,
+		clock:          hlc.NewClock(regionID),
+		logger:         logging.Default(),
+		observer:       nopObserver{},
+		tombstoneGrace: DefaultTombstoneGracePeriod,
+		replies:        make(chan func() error, maxQueuedReplies),
+		stopped:        make(chan struct{}),
+	}
+	d.SetRegions(regions)
+	return d
+}
+
+// SetLogger replaces the logger, which writes info and above to stderr by default.
+// It must be called before the delegate is used.
+func (d *Delegate) SetLogger(l logging.Logger) {
+	d.logger = l
+}
+
+// gossip is what the delegate uses of memberlist.
+type gossip interface {
+	LocalNode() *memberlist.Node
+	NumMembers() int
+	SendReliable(to *memberlist.Node, msg []byte) error
+}
+
+// SetMemberlist attaches the delegate to the cluster it gossips in and
+// starts the reply workers. It must be called before the node joins other
+// members, and Stop once it leaves.
+func (d *Delegate) SetMemberlist(ml *memberlist.Memberlist) {
+	d.attach(ml)
+	for i := 0; i < replyWorkers; i++ {
+		d.workers.Add(1)
+		go d.work()
+	}
+}
+
+func (d *Delegate) attach(g gossip) {
+	d.clusterMu.Lock()
+	defer d.clusterMu.Unlock()
+	d.memberlist = g
+	d.broadcasts = &memberlist.TransmitLimitedQueue{
+		NumNodes:       g.NumMembers,
+		RetransmitMult: retransmitMult,
+	}
+}
+
+// cluster returns the memberlist the delegate is attached to and its
+// broadcast queue, both nil until SetMemberlist.
+func (d *Delegate) cluster() (gossip, *memberlist.TransmitLimitedQueue) {
+	d.clusterMu.RLock()
+	defer d.clusterMu.RUnlock()
+	return d.memberlist, d.broadcasts
+}
+
+func (d *Delegate) localName() string {
+	ml, _ := d.cluster()
+	if ml == nil {
+		return ""
+	}
+	return ml.LocalNode().Name
+}
+
+// Recover reloads the events persisted by a previous run so replication
+// resumes where it left off. Every stored event has been committed by this
+// region, which is recorded again in case the node crashed before its
+// commit was written. It returns the number of events found and how many
+// of them are still waiting for other regions.
+func (d *Delegate) Recover() (int, int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var total, pending int
+	regions := d.Regions()
+	repaired := make(map[string][]byte)
+	err := d.iterate(func(key string, val []byte) error {
+		var v V
+		if err := json.Unmarshal(val, &v); err != nil {
+			d.logger.Warn("skipping unreadable event on recovery", "key", key, "error", err)
+			return nil
+		}
+		total++
+		// never stamp new writes below what is already stored
+		if err := d.clock.Update(v.Meta.HLC); err != nil {
+			d.logger.Warn("stored event stamped ahead of the local clock", "key", key, "error", err)
+		}
+		if !v.Meta.CommitedRegions[d.regionID] {
+			v.Meta.CommitedRegions = v.Meta.CommitedRegions.Add(d.regionID)
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			repaired[key] = b
+		}
+		if !complete(v.Meta, regions) {
+			pending++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	for key, b := range repaired {
+		err = d.backend.Put(key, b)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return total, pending, nil
+}
+
+// NodeMeta is used to retrieve meta-data about the current node
+// when broadcasting an alive message. It's length is limited to
+// the given byte size. This metadata is available in the Node structure.
+func (d *Delegate) NodeMeta(limit int) []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b, err := d.metadata.Encode(limit)
+	if err != nil {
+		d.logger.Error("failed to encode metadata, not sharing it", "limit", limit, "error", err)
+		return nil
+	}
+	return b
+}
+
+// SetMetadata sets an extra metadata entry shared with peers. They learn
+// about it on the next memberlist UpdateNode.
+func (d *Delegate) SetMetadata(key, value string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	extra := make(map[string]string, len(d.metadata.Extra)+1)
+	for k, v := range d.metadata.Extra {
+		extra[k] = v
+	}
+	extra[key] = value
+	d.metadata.Extra = extra
+}
+
+// SetAPIAddr sets the API address shared with peers, e.g. once the port
+// picked by the system is known.
+func (d *Delegate) SetAPIAddr(addr string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.metadata.APIAddr = addr
+}
+
+// NotifyMsg is called when a user-data message is received.
+// Care should be taken that this method does not block, since doing
+// so would block the entire UDP packet receive loop. Additionally, the byte
+// slice may be modified after the call returns, so it should be copied if needed
+//
+// Values received are merged right away. Digest and pull messages need a
+// network round trip to answer and are queued for the reply workers;
+// they are dropped when the queue is full, the next push/pull asks again.
+func (d *Delegate) NotifyMsg(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	msgType := b[0]
+	buf := append([]byte{}, b[1:]...)
+
+	switch msgType {
+	case msgDigest, msgPull:
+		d.enqueue(func() error {
+			defer d.recoverPayload(PayloadMessage, buf)
+			return d.handleMessage(msgType, buf)
+		})
+	default:
+		defer d.recoverPayload(PayloadMessage, buf)
+		err := d.handleMessage(msgType, buf)
+		if err != nil {
+			d.logger.Warn("failed to handle message", "type", msgType, "error", err)
+		}
+	}
+}
+
+func (d *Delegate) handleMessage(msgType byte, buf []byte) error {
+	decoder := gob.NewDecoder(bytes.NewReader(buf))
+	switch msgType {
+	case msgDigest:
+		var msg digestMsg
+		if err := decoder.Decode(&msg); err != nil {
+			d.reject(PayloadMessage, "", buf, fmt.Errorf("decode digest: %w", err))
+			return nil
+		}
+		return d.handleDigest(msg)
+	case msgPull:
+		var msg pullMsg
+		if err := decoder.Decode(&msg); err != nil {
+			d.reject(PayloadMessage, "", buf, fmt.Errorf("decode pull: %w", err))
+			return nil
+		}
+		return d.handlePull(msg)
+	case msgEntries:
+		var msg entriesMsg
+		if err := decoder.Decode(&msg); err != nil {
+			d.reject(PayloadMessage, "", buf, fmt.Errorf("decode entries: %w", err))
+			return nil
+		}
+		d.handleEntries(msg)
+		return nil
+	case msgPut:
+		d.handlePut(buf)
+		return nil
+	default:
+		d.reject(PayloadMessage, "", buf, fmt.Errorf("unknown message type %d", msgType))
+		return nil
+	}
+}
+
+// recoverPayload quarantines a payload whose handling panicked, so that a
+// single peer cannot take the node down. It must be deferred.
+func (d *Delegate) recoverPayload(kind string, payload []byte) {
+	if r := recover(); r != nil {
+		d.reject(kind, "", payload, fmt.Errorf("panic: %v", r))
+	}
+}
+
+// GetBroadcasts is called when user data messages can be broadcast.
+// It can return a list of buffers to send. Each buffer should assume an
+// overhead as provided with a limit on the total byte size allowed.
+// The total byte size of the resulting data to send must not exceed
+// the limit. Care should be taken that this method does not block,
+// since doing so would block the entire UDP packet receive loop.
+func (d *Delegate) GetBroadcasts(overhead, limit int) [][]byte {
+	_, broadcasts := d.cluster()
+	if broadcasts == nil {
+		return nil
+	}
+	return broadcasts.GetBroadcasts(overhead, limit)
+}
+
+// LocalState is used for a TCP Push/Pull. This is sent to
+// the remote side in addition to the membership information. Any
+// data can be sent here. See MergeRemoteState as well. The `join`
+// boolean indicates this is for a join instead of a push/pull.
+func (d *Delegate) LocalState(join bool) []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil
+	}
+
+	var network bytes.Buffer
+	encoder := gob.NewEncoder(&network)
+	summary, err := d.summary()
+	if err != nil {
+		// the peer skips merging an empty state, the next push/pull tries again
+		d.logger.Error("failed to read local state", "error", err)
+		return nil
+	}
+	err = encoder.Encode(summary)
+	if err != nil {
+		d.logger.Error("failed to encode local state", "error", err)
+		return nil
+	}
+	return network.Bytes()
+}
+
+// MergeRemoteState is invoked after a TCP Push/Pull. This is the
+// state received from the remote side and is the result of the
+// remote side's LocalState call. The 'join'
+// boolean indicates this is for a join instead of a push/pull.
+func (d *Delegate) MergeRemoteState(buf []byte, join bool) {
+	defer d.recoverPayload(PayloadState, buf)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+
+	network := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(network)
+	var remote stateSummary
+	err := decoder.Decode(&remote)
+	if err != nil {
+		d.reject(PayloadState, "", buf, err)
+		return
+	}
+	if remote.Node == d.localName() {
+		return
+	}
+
+	if remote.Count == 0 {
+		// the remote side already dropped everything, including what we still hold for deletion
+		err = d.purgeDeleted()
+		if err != nil {
+			d.logger.Error("failed to purge deleted events", "error", err)
+		}
+	}
+
+	local, err := d.summary()
+	if err != nil {
+		d.logger.Error("failed to read local state", "error", err)
+		return
+	}
+	var diff []int
+	for i := range local.Buckets {
+		if i >= len(remote.Buckets) || local.Buckets[i] != remote.Buckets[i] {
+			diff = append(diff, i)
+		}
+	}
+	if len(diff) == 0 {
+		return
+	}
+	// both sides merge the state of the other after a push/pull, the one
+	// with the smaller name starts the exchange, which syncs both ways
+	if d.localName() > remote.Node {
+		return
+	}
+
+	d.logger.Debug("state differs from peer", "peer", remote.Node, "buckets", len(diff), "of", numBuckets)
+	d.enqueue(func() error {
+		err := d.sendDigests(remote.Node, diff)
+		if err != nil {
+			return fmt.Errorf("send digests to %s: %w", remote.Node, err)
+		}
+		return nil
+	})
+}
+
+// mergeEntry applies a single remote value and reports whether it changed
+// the local state; values the local copy already covers are skipped.
+func (d *Delegate) mergeEntry(key string, value []byte) bool {
+	if IsLocal(key) {
+		d.reject(PayloadEntry, key, value, ErrReservedKey)
+		return false
+	}
+	var vin V
+	err := json.Unmarshal(value, &vin)
+	if err != nil {
+		d.reject(PayloadEntry, key, value, err)
+		return false
+	}
+	err = d.clock.Update(vin.Meta.HLC)
+	if err != nil {
+		// stamped by a peer whose clock runs ahead: early, not bad
+		d.deferSkewed(key, value, vin.Meta.HLC, err)
+		return false
+	}
+
+	if vin.Meta.ToDelete {
+		newer, err := d.hasNewer(key, vin)
+		if err != nil {
+			d.logger.Error("failed to read event to delete", "key", key, "error", err)
+			return false
+		}
+		if !newer {
+			err = d.forget(key, value)
+			if err != nil {
+				d.logger.Error("failed to delete committed event", "key", key, "error", err)
+				return false
+			}
+			d.logger.Debug("deleted event committed by every region", "key", key)
+			return true
+		}
+		// only the version committed everywhere is done with, not the
+		// ones written since
+		vin.Meta.ToDelete = false
+	}
+	if d.expired(vin, d.Regions(), time.Now()) {
+		if _, err := d.backend.Get(key); err == ErrKeyNotFound {
+			// collected here already, storing it again would only bounce
+			// it between peers
+			return false
+		}
+	}
+
+	applied, err := d.mergeValue(key, vin)
+	if err != nil {
+		d.logger.Error("failed to merge event", "key", key, "version", vin.Meta.Version, "error", err)
+	}
+	return applied
+}
+
+// hasNewer reports whether the local copy of key holds a version that
+// vin does not cover.
+func (d *Delegate) hasNewer(key string, vin V) (bool, error) {
+	local, err := d.load(key)
+	if err == ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, version := range local.versions() {
+		switch version.Meta.Vector.Compare(vin.Context()) {
+		case After, Concurrent:
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mergeValue applies a single remote value on top of the local copy. The
+// regions that committed a version are merged by union whichever copy
+// wins, so commit progress never regresses; it reports whether the stored
+// value changed.
+func (d *Delegate) mergeValue(key string, vin V) (bool, error) {
+	var local []V
+	b, err := d.backend.Get(key)
+	switch {
+	case err == ErrKeyNotFound:
+		d.logger.Debug("storing new event", "key", key, "version", vin.Meta.Version, "data", logging.Payload(vin.Data))
+	case err != nil:
+		return false, err
+	default:
+		var vexit V
+		err = json.Unmarshal(b, &vexit)
+		if err != nil {
+			d.logger.Warn("replacing unreadable local event", "key", key, "error", err)
+		}
+		local = vexit.versions()
+	}
+
+	versions := reconcile(local, vin.versions())
+	for i := range versions {
+		versions[i].Meta.CommitedRegions = versions[i].Meta.CommitedRegions.Add(d.regionID)
+	}
+	result := versions[0]
+	result.Siblings = versions[1:]
+	// tombstones outlive their commit for a grace period, see CollectTombstones,
+	// and conflicts stay until the application resolves them
+	if !result.Meta.Tombstone && len(result.Siblings) == 0 &&
+		complete(result.Meta, d.Regions()) {
+		result.Meta.ToDelete = true
+	}
+	commitedV, err := json.Marshal(result)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(commitedV, b) {
+		// a copy of what is already stored, e.g. a retransmitted broadcast
+		return false, nil
+	}
+	err = d.backend.Put(key, commitedV)
+	if err != nil {
+		return false, err
+	}
+	if len(result.Siblings) > 0 {
+		d.logger.Info("kept concurrent versions", "key", key, "versions", len(versions))
+	}
+	d.logger.Debug("merged event", "key", key, "version", result.Meta.Version, "regions", len(result.Meta.CommitedRegions))
+	return true, nil
+}
@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+)
+
+// memBackend is a Backend keeping everything in a map.
+type memBackend map[string][]byte
+
+func (m memBackend) Put(key string, value []byte) error {
+	m[key] = append([]byte{}, value...)
+	return nil
+}
+
+func (m memBackend) Get(key string) ([]byte, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return append([]byte{}, v...), nil
+}
+
+func (m memBackend) Del(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m memBackend) Iterate(prefix string, fn func(key string, value []byte) error) error {
+	return m.Scan(prefix, "", fn)
+}
+
+func (m memBackend) Scan(prefix, start string, fn func(key string, value []byte) error) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if strings.HasPrefix(k, prefix) && k >= start {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		err := fn(k, m[k])
+		if err == ErrStopIteration {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m memBackend) Snapshot() (map[string][]byte, error) {
+	out := make(map[string][]byte, len(m))
+	for k, v := range m {
+		out[k] = append([]byte{}, v...)
+	}
+	return out, nil
+}
+
+func (m memBackend) Close() error { return nil }
+
+var threeRegions = []uint{1, 2, 3}
+
+// countingObserver counts what a delegate reports.
+type countingObserver struct {
+	nopObserver
+	dropped int
+}
+
+func (o *countingObserver) MessageDropped() { o.dropped++ }
+
+func newTestDelegate(region uint) *Delegate {
+	d := NewDelegate(memBackend{}, nodemeta.Meta{Region: region}, region, threeRegions)
+	d.SetLogger(logging.Nop())
+	return d
+}
+
+func TestNotifyMsgDropsRepliesWhenQueueIsFull(t *testing.T) {
+	d := newTestDelegate(1)
+	obs := &countingObserver{}
+	d.SetObserver(obs)
+
+	// no reply worker runs until the delegate is attached to a cluster
+	for i := 0; i < maxQueuedReplies+10; i++ {
+		d.NotifyMsg([]byte{msgPull})
+	}
+	if obs.dropped != 10 {
+		t.Fatalf("dropped %d messages, want 10", obs.dropped)
+	}
+
+	// values are merged right away, whatever the queue holds
+	src := newTestDelegate(2)
+	v, err := src.Write(V{ID: "k", Data: []byte("v")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := src.backend.Get(v.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.NotifyMsg(append([]byte{msgPut}, b...))
+	if _, err := d.load("k"); err != nil {
+		t.Fatalf("broadcast not merged: %v", err)
+	}
+	if obs.dropped != 10 {
+		t.Fatalf("dropped %d messages, want 10", obs.dropped)
+	}
+}
+
+func TestForgottenEventsAreNotStoredAgain(t *testing.T) {
+	c := newCluster()
+	if _, err := c[1].Write(V{ID: "k", Data: []byte("v")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	stale, _ := c[1].backend.Get("k")
+	c.send(t, 1, 2, "k")
+	c.send(t, 2, 3, "k")
+	// region 3 saw every commit and marks the event for deletion
+	if v, ok := c.load(t, 3, "k"); !ok || !v.Meta.ToDelete {
+		t.Fatalf("region 3 holds %+v, want it marked for deletion", v)
+	}
+	c.send(t, 3, 1, "k")
+	if _, ok := c.load(t, 1, "k"); ok {
+		t.Fatal("region 1 kept the event committed everywhere")
+	}
+
+	// a broadcast of the first write arrives late
+	c[1].NotifyMsg(append([]byte{msgPut}, stale...))
+	if _, ok := c.load(t, 1, "k"); ok {
+		t.Fatal("a stale copy of a deleted event was stored again")
+	}
+
+	// a newer write is not stale
+	if _, err := c[2].Write(V{ID: "k", Data: []byte("v2")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	newer, _ := c[2].backend.Get("k")
+	c[1].NotifyMsg(append([]byte{msgPut}, newer...))
+	if v, ok := c.load(t, 1, "k"); !ok || string(v.Data) != "v2" {
+		t.Fatalf("region 1 holds %+v, want the newer write", v)
+	}
+}
+
+func TestStopEndsMerges(t *testing.T) {
+	d := newTestDelegate(1)
+	src := newTestDelegate(2)
+	if _, err := src.Write(V{ID: "k", Data: []byte("v")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := src.backend.Get("k")
+
+	d.Stop()
+	d.NotifyMsg(append([]byte{msgPut}, b...))
+	d.handleEntries(entriesMsg{From: "b", Entries: map[string][]byte{"k": b}})
+	d.MergeRemoteState(src.LocalState(false), false)
+	if _, err := d.backend.Get("k"); err != ErrKeyNotFound {
+		t.Fatalf("a stopped delegate merged a peer value: %v", err)
+	}
+	if d.LocalState(false) != nil {
+		t.Fatal("a stopped delegate shared its state")
+	}
+}
+
+func TestLocalStateIsNotAnEvent(t *testing.T) {
+	d := newTestDelegate(1)
+	if err := d.PutLocal("regions", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Write(V{ID: "a", Data: []byte("v")}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if b, err := d.GetLocal("regions"); err != nil || string(b) != `{}` {
+		t.Fatalf("read back %q, %v", b, err)
+	}
+	if stats, err := d.Stats(); err != nil || stats.Events != 1 {
+		t.Fatalf("counted %+v, %v, want only the event", stats, err)
+	}
+	if data, err := d.entries([]string{localPrefix + "regions"}); err != nil || len(data) != 0 {
+		t.Fatalf("sent %v, %v for the node state, want nothing", data, err)
+	}
+
+	// neither a client nor a peer can write over it
+	if _, err := d.Write(V{ID: localPrefix + "regions", Data: []byte("v")}, nil); err != ErrReservedKey {
+		t.Fatalf("writing a reserved id returned %v, want ErrReservedKey", err)
+	}
+	b, _ := json.Marshal(V{ID: localPrefix + "regions", Data: []byte("v")})
+	if d.mergeEntry(localPrefix+"regions", b) {
+		t.Fatal("merged a peer entry under a reserved id")
+	}
+	if b, _ := d.GetLocal("regions"); string(b) != `{}` {
+		t.Fatalf("node state overwritten with %q", b)
+	}
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// forgetRetention is how long a node remembers the events it deleted once
+// every region committed them. Peers that have not heard of the deletion
+// yet still send their copy, which is turned back rather than stored again.
+const forgetRetention = 10 * time.Minute
+
+// forgottenEvent is an event deleted once every region committed it.
+type forgottenEvent struct {
+	// value is the copy marked for deletion, sent to peers still holding
+	// an older one
+	value   []byte
+	context VersionVector
+	at      time.Time
+}
+
+// forget deletes the local copy of an event every region committed, value
+// being a copy marked for deletion, and remembers it for forgetRetention.
+// It must be called with d.mu held.
+func (d *Delegate) forget(key string, value []byte) error {
+	err := d.backend.Del(key)
+	if err != nil {
+		return err
+	}
+	var v V
+	if err := json.Unmarshal(value, &v); err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if d.forgotten == nil {
+		d.forgotten = make(map[string]forgottenEvent)
+	}
+	if now.Sub(d.forgottenPruned) > forgetRetention {
+		for k, f := range d.forgotten {
+			if now.Sub(f.at) > forgetRetention {
+				delete(d.forgotten, k)
+			}
+		}
+		d.forgottenPruned = now
+	}
+	d.forgotten[key] = forgottenEvent{
+		value:   append([]byte{}, value...),
+		context: v.Context(),
+		at:      now,
+	}
+	return nil
+}
+
+// forgottenCopy returns the copy marked for deletion of an event this node
+// forgot recently and no longer stores. It must be called with d.mu held.
+func (d *Delegate) forgottenCopy(key string) ([]byte, bool) {
+	f, ok := d.forgotten[key]
+	if !ok || time.Since(f.at) > forgetRetention {
+		return nil, false
+	}
+	if _, err := d.backend.Get(key); err != ErrKeyNotFound {
+		return nil, false
+	}
+	return f.value, true
+}
+
+// stale returns the copy marked for deletion of an event forgotten
+// recently if value is an older copy of it, which must not be stored
+// again. It must be called with d.mu held.
+func (d *Delegate) stale(key string, value []byte) ([]byte, bool) {
+	forgotten, ok := d.forgottenCopy(key)
+	if !ok {
+		return nil, false
+	}
+	var v V
+	if err := json.Unmarshal(value, &v); err != nil || v.Meta.ToDelete {
+		return nil, false
+	}
+	ctx := d.forgotten[key].context
+	for _, version := range v.versions() {
+		switch version.Meta.Vector.Compare(ctx) {
+		case After, Concurrent:
+			return nil, false
+		}
+	}
+	return forgotten, true
+}
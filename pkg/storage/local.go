@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+// localPrefix starts the keys holding the state of the node itself, such as
+// the region registry it follows, next to the events. They are neither
+// gossiped nor listed, and no event id can start with it.
+const localPrefix = "\x00"
+
+// ErrReservedKey is returned when writing an event whose id starts with
+// the prefix of the node state.
+var ErrReservedKey = errors.New("storage: ids starting with a NUL byte are reserved")
+
+// IsLocal reports whether key holds node state rather than an event.
+func IsLocal(key string) bool {
+	return strings.HasPrefix(key, localPrefix)
+}
+
+// PutLocal stores node state under name, next to the events.
+func (d *Delegate) PutLocal(name string, value []byte) error {
+	return d.backend.Put(localPrefix+name, value)
+}
+
+// GetLocal returns the node state stored under name, or ErrKeyNotFound.
+func (d *Delegate) GetLocal(name string) ([]byte, error) {
+	return d.backend.Get(localPrefix + name)
+}
+
+// iterate calls fn for every event stored, in key order, skipping the
+// node state.
+func (d *Delegate) iterate(fn func(key string, value []byte) error) error {
+	return d.backend.Iterate("", func(key string, value []byte) error {
+		if IsLocal(key) {
+			return nil
+		}
+		return fn(key, value)
+	})
+}
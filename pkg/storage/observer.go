@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Sources of the remote entries reported to an Observer.
+const (
+	SourcePushPull  = "pushpull"
+	SourceBroadcast = "broadcast"
+)
+
+// Observer is told about the replication work done by a Delegate, e.g. to
+// export metrics. Its methods are called with the delegate lock held and
+// must not block.
+type Observer interface {
+	// Merged reports a batch of remote entries: how many were received and
+	// how many changed the local state, the others being skipped.
+	Merged(source string, received, applied int, elapsed time.Duration)
+
+	// TombstonesCollected reports how many tombstones were garbage collected.
+	TombstonesCollected(count int)
+
+	// MessageDropped reports a message from a peer dropped because the
+	// reply workers were behind.
+	MessageDropped()
+}
+
+type nopObserver struct{}
+
+func (nopObserver) Merged(string, int, int, time.Duration) {}
+func (nopObserver) TombstonesCollected(int)                {}
+func (nopObserver) MessageDropped()                        {}
+
+// SetObserver registers the observer of the delegate. It must be called
+// before the delegate is used.
+func (d *Delegate) SetObserver(o Observer) {
+	d.observer = o
+}
+
+// Stats describes the events held locally.
+type Stats struct {
+	// Events is the number of stored events, tombstones included.
+	Events int
+
+	// Tombstones is the number of deleted events not collected yet.
+	Tombstones int
+
+	// Pending counts, for every region, the events it has not committed yet.
+	Pending map[uint]int
+}
+
+// Stats walks the local events, counting pending events for the regions
+// that must commit them.
+func (d *Delegate) Stats() (Stats, error) {
+	regions := d.Regions()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	s := Stats{Pending: make(map[uint]int, len(regions))}
+	for _, region := range regions {
+		s.Pending[region] = 0
+	}
+	err := d.iterate(func(key string, val []byte) error {
+		var v V
+		if err := json.Unmarshal(val, &v); err != nil {
+			return nil
+		}
+		s.Events++
+		if v.Meta.Tombstone {
+			s.Tombstones++
+		}
+		for region := range s.Pending {
+			if !v.Meta.CommitedRegions[region] {
+				s.Pending[region]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Stats{}, err
+	}
+	return s, nil
+}
+
+// PendingEvent is an event some regions have not committed yet.
+type PendingEvent struct {
+	Key string
+
+	// WrittenAt is the wall time of the last write, zero for events
+	// written before timestamps were recorded.
+	WrittenAt time.Time
+
+	// Missing lists the regions that have not committed the event, in order.
+	Missing []uint
+}
+
+// Pending calls fn for every event some region has not committed yet and
+// returns the number of events stored.
+func (d *Delegate) Pending(fn func(PendingEvent)) (int, error) {
+	regions := d.Regions()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var total int
+	err := d.iterate(func(key string, val []byte) error {
+		var v V
+		if err := json.Unmarshal(val, &v); err != nil {
+			return nil
+		}
+		total++
+		var missing []uint
+		for _, region := range regions {
+			if !v.Meta.CommitedRegions[region] {
+				missing = append(missing, region)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		p := PendingEvent{Key: key, Missing: missing}
+		if !v.Meta.HLC.IsZero() {
+			p.WrittenAt = time.Unix(0, v.Meta.HLC.WallTime)
+		}
+		fn(p)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/memberlist"
+)
+
+// peers remembers how to reach the alive members. memberlist updates the
+// nodes returned by Members in place, so their address is copied when
+// memberlist reports them rather than read at send time.
+type peers struct {
+	mu    sync.Mutex
+	nodes map[string]memberlist.Node
+}
+
+func (p *peers) set(n *memberlist.Node) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.nodes == nil {
+		p.nodes = make(map[string]memberlist.Node)
+	}
+	p.nodes[n.Name] = memberlist.Node{
+		Name: n.Name,
+		Addr: append(net.IP{}, n.Addr...),
+		Port: n.Port,
+	}
+}
+
+func (p *peers) remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.nodes, name)
+}
+
+func (p *peers) get(name string) (memberlist.Node, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n, ok := p.nodes[name]
+	return n, ok
+}
+
+// names returns the names of the peers, sorted.
+func (p *peers) names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	names := make([]string, 0, len(p.nodes))
+	for name := range p.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PeerAlive records the address of a member memberlist reported alive or
+// updated. It must be called from a memberlist EventDelegate, while the
+// node is safe to read.
+func (d *Delegate) PeerAlive(n *memberlist.Node) {
+	d.peers.set(n)
+}
+
+// PeerGone forgets a member that died or left.
+func (d *Delegate) PeerGone(name string) {
+	d.peers.remove(name)
+}
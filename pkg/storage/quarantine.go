@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/logging"
+)
+
+// maxQuarantined bounds how many bad payloads are kept for inspection;
+// the oldest ones are dropped first.
+const maxQuarantined = 100
+
+// Payload kinds recorded in quarantine.
+const (
+	PayloadState     = "state"
+	PayloadMessage   = "message"
+	PayloadEntry     = "entry"
+	PayloadBroadcast = "broadcast"
+)
+
+// BadPayload is a gossip payload that could not be decoded or applied.
+type BadPayload struct {
+	// Kind is where the payload came from: push/pull state, user message,
+	// replicated entry or broadcast.
+	Kind string
+
+	// Key is the event the payload was for, if known.
+	Key string
+
+	// Payload is a copy of the raw bytes received.
+	Payload []byte
+
+	// Err is why the payload was rejected.
+	Err error
+
+	At time.Time
+}
+
+// quarantine keeps the last bad payloads received from peers so that they
+// can be inspected, instead of taking the node down.
+type quarantine struct {
+	mu       sync.Mutex
+	total    uint64
+	payloads []BadPayload
+}
+
+func (q *quarantine) add(p BadPayload) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.total++
+	if len(q.payloads) == maxQuarantined {
+		q.payloads = append(q.payloads[:0], q.payloads[1:]...)
+	}
+	q.payloads = append(q.payloads, p)
+}
+
+// reject quarantines a payload that could not be decoded or applied.
+func (d *Delegate) reject(kind, key string, payload []byte, err error) {
+	fields := []interface{}{"kind", kind, "payload", logging.Payload(payload), "error", err}
+	if key != "" {
+		fields = append(fields, "key", key)
+	}
+	d.logger.Warn("quarantined bad payload", fields...)
+	d.quarantine.add(BadPayload{
+		Kind:    kind,
+		Key:     key,
+		Payload: append([]byte{}, payload...),
+		Err:     err,
+		At:      time.Now(),
+	})
+}
+
+// BadPayloads returns how many bad payloads were received since the node started.
+func (d *Delegate) BadPayloads() uint64 {
+	d.quarantine.mu.Lock()
+	defer d.quarantine.mu.Unlock()
+	return d.quarantine.total
+}
+
+// Quarantined returns the last bad payloads received, oldest first.
+func (d *Delegate) Quarantined() []BadPayload {
+	d.quarantine.mu.Lock()
+	defer d.quarantine.mu.Unlock()
+	return append([]BadPayload{}, d.quarantine.payloads...)
+}
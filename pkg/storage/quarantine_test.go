@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/hlc"
+)
+
+func TestBadPayloadsAreQuarantined(t *testing.T) {
+	garbage := []byte("\x00\xffnot gob nor json")
+	tests := []struct {
+		name    string
+		send    func(d *Delegate)
+		kind    string
+		key     string
+		payload []byte
+	}{
+		{
+			name:    "push/pull state",
+			send:    func(d *Delegate) { d.MergeRemoteState(garbage, false) },
+			kind:    PayloadState,
+			payload: garbage,
+		},
+		{
+			name:    "unknown message",
+			send:    func(d *Delegate) { d.NotifyMsg(append([]byte{0xff}, garbage...)) },
+			kind:    PayloadMessage,
+			payload: garbage,
+		},
+		{
+			name:    "entries message",
+			send:    func(d *Delegate) { d.NotifyMsg(append([]byte{msgEntries}, garbage...)) },
+			kind:    PayloadMessage,
+			payload: garbage,
+		},
+		{
+			name:    "broadcast",
+			send:    func(d *Delegate) { d.NotifyMsg(append([]byte{msgPut}, garbage...)) },
+			kind:    PayloadBroadcast,
+			payload: garbage,
+		},
+		{
+			name:    "put",
+			send:    func(d *Delegate) { d.handlePut([]byte(`{"id": 42}`)) },
+			kind:    PayloadBroadcast,
+			payload: []byte(`{"id": 42}`),
+		},
+		{
+			name: "replicated entry",
+			send: func(d *Delegate) {
+				d.handleEntries(entriesMsg{From: "b", Entries: map[string][]byte{"k": garbage}})
+			},
+			kind:    PayloadEntry,
+			key:     "k",
+			payload: garbage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDelegate(1)
+			tt.send(d)
+
+			if n := d.BadPayloads(); n != 1 {
+				t.Fatalf("%d bad payloads counted, want 1", n)
+			}
+			q := d.Quarantined()
+			if len(q) != 1 {
+				t.Fatalf("%d payloads quarantined, want 1", len(q))
+			}
+			if q[0].Kind != tt.kind || q[0].Key != tt.key || q[0].Err == nil {
+				t.Errorf("quarantined %s payload for %q (%v), want a %s payload for %q",
+					q[0].Kind, q[0].Key, q[0].Err, tt.kind, tt.key)
+			}
+			if !bytes.Equal(q[0].Payload, tt.payload) {
+				t.Errorf("quarantined %q, want %q", q[0].Payload, tt.payload)
+			}
+
+			// the node still merges what peers send
+			src := newTestDelegate(2)
+			if _, err := src.Write(V{ID: "good", Data: []byte("v")}, nil); err != nil {
+				t.Fatal(err)
+			}
+			b, _ := src.backend.Get("good")
+			d.NotifyMsg(append([]byte{msgPut}, b...))
+			if _, err := d.load("good"); err != nil {
+				t.Fatalf("broadcast not merged after a bad payload: %v", err)
+			}
+		})
+	}
+}
+
+func TestQuarantineKeepsTheLastPayloads(t *testing.T) {
+	d := newTestDelegate(1)
+	for i := 0; i < maxQuarantined+5; i++ {
+		d.handlePut([]byte{byte(i)})
+	}
+	if n := d.BadPayloads(); n != maxQuarantined+5 {
+		t.Fatalf("%d bad payloads counted, want %d", n, maxQuarantined+5)
+	}
+	q := d.Quarantined()
+	if len(q) != maxQuarantined {
+		t.Fatalf("%d payloads kept, want %d", len(q), maxQuarantined)
+	}
+	if q[0].Payload[0] != 5 || q[len(q)-1].Payload[0] != maxQuarantined+4 {
+		t.Errorf("kept payloads %d to %d, want the last ones", q[0].Payload[0], q[len(q)-1].Payload[0])
+	}
+}
+
+func TestSkewedEntriesAreMergedLater(t *testing.T) {
+	d := newTestDelegate(1)
+	d.SetMaxClockOffset(100 * time.Millisecond)
+	d.workers.Add(1)
+	go d.work()
+	defer d.Stop()
+
+	// written by a node whose clock is 300ms ahead
+	stamped := hlc.Timestamp{WallTime: time.Now().Add(300 * time.Millisecond).UnixNano(), Region: 2}
+	future, err := json.Marshal(V{ID: "k", Data: []byte("v"), Meta: Meta{
+		HLC:             stamped,
+		CommitedRegions: CommitSet{}.Add(2),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.handleEntries(entriesMsg{From: "b", Entries: map[string][]byte{"k": future}})
+
+	// neither merged nor quarantined, only counted
+	if _, err := d.load("k"); err != ErrKeyNotFound {
+		t.Fatalf("loaded an entry beyond the maximum offset: %v", err)
+	}
+	if n := d.BadPayloads(); n != 0 {
+		t.Errorf("%d bad payloads counted, want none", n)
+	}
+	if n := d.ClockSkews(); n != 1 {
+		t.Errorf("%d clock skews counted, want 1", n)
+	}
+
+	// merged once the local clock caught up, and later writes follow it
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		d.mu.Lock()
+		_, err := d.load("k")
+		d.mu.Unlock()
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("entry not merged once the clock caught up: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if now := d.clock.Now(); now.Compare(stamped) <= 0 {
+		t.Errorf("clock at %+v after merging, want it past %+v", now, stamped)
+	}
+}
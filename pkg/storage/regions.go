@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/nodemeta"
+)
+
+// regionSet holds the regions that must commit an event before it is
+// complete. It follows the topology of the cluster, see SetRegions.
+type regionSet struct {
+	mu      sync.RWMutex
+	regions []uint
+}
+
+// Regions returns the regions that must commit an event, in order.
+func (d *Delegate) Regions() []uint {
+	d.regions.mu.RLock()
+	defer d.regions.mu.RUnlock()
+	return append([]uint{}, d.regions.regions...)
+}
+
+// SetRegions replaces the regions that must commit an event; the region of
+// the node is always one of them. It reports whether the set changed. Call
+// ReevaluateCommits afterwards when regions were removed.
+func (d *Delegate) SetRegions(regions []uint) bool {
+	set := map[uint]bool{d.regionID: true}
+	for _, r := range regions {
+		if r != 0 {
+			set[r] = true
+		}
+	}
+	sorted := make([]uint, 0, len(set))
+	for r := range set {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	d.regions.mu.Lock()
+	defer d.regions.mu.Unlock()
+	if equalRegions(d.regions.regions, sorted) {
+		return false
+	}
+	d.regions.regions = sorted
+	return true
+}
+
+// SetRegistry sets the region registry shared with peers through the node
+// metadata. Like SetMetadata, it is gossiped with the next alive message.
+func (d *Delegate) SetRegistry(r nodemeta.Registry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	changed := make(map[uint]uint64, len(r.Changed))
+	for region, version := range r.Changed {
+		changed[region] = version
+	}
+	d.metadata.Registry = nodemeta.Registry{
+		Version: r.Version,
+		Regions: append([]uint{}, r.Regions...),
+		Retired: append([]uint{}, r.Retired...),
+		Changed: changed,
+	}
+}
+
+// complete reports whether every region committed the event.
+func complete(m Meta, regions []uint) bool {
+	for _, r := range regions {
+		if !m.CommitedRegions[r] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalRegions(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
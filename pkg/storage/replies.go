@@ -0,0 +1,53 @@
+package storage
+
+const (
+	// maxQueuedReplies bounds the work waiting for the reply workers; more
+	// is dropped.
+	maxQueuedReplies = 256
+
+	// replyWorkers answer peers concurrently.
+	replyWorkers = 4
+)
+
+// enqueue hands fn to the reply workers, or drops it if they are behind or
+// stopped. Anti-entropy retries what is dropped on the next push/pull.
+func (d *Delegate) enqueue(fn func() error) {
+	select {
+	case <-d.stopped:
+		return
+	default:
+	}
+	select {
+	case d.replies <- fn:
+	default:
+		d.observer.MessageDropped()
+		d.logger.Debug("dropped message, reply queue is full", "queued", len(d.replies))
+	}
+}
+
+// work runs queued replies until the delegate stops.
+func (d *Delegate) work() {
+	defer d.workers.Done()
+	for {
+		select {
+		case <-d.stopped:
+			return
+		case fn := <-d.replies:
+			err := fn()
+			if err != nil {
+				d.logger.Warn("failed to answer peer", "error", err)
+			}
+		}
+	}
+}
+
+// Stop stops the reply workers and waits for the replies and merges in
+// progress; work still queued is dropped. Once it returns, peers no longer
+// reach the backend, which can be closed.
+func (d *Delegate) Stop() {
+	d.stopOnce.Do(func() { close(d.stopped) })
+	d.workers.Wait()
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyawmyintthein/gossip-replicator/pkg/hlc"
+)
+
+// maxSkewed bounds how many entries stamped ahead of the local clock wait
+// to be merged; more are dropped and left to anti-entropy.
+const maxSkewed = 1024
+
+// skewed holds the entries from peers whose timestamp is beyond the maximum
+// clock offset, see hlc.ErrClockOffset. They are valid, only early: each is
+// merged once the local clock catches up with it.
+type skewed struct {
+	mu      sync.Mutex
+	total   uint64
+	entries map[string][]byte
+}
+
+// SetMaxClockOffset sets how far ahead of the local clock the timestamp of
+// an entry from a peer may be; entries further ahead are merged once the
+// local clock catches up. 0 accepts any timestamp. It must be called before
+// the delegate is used.
+func (d *Delegate) SetMaxClockOffset(offset time.Duration) {
+	d.clock.SetMaxOffset(offset)
+}
+
+// ClockSkews returns how many entries from peers were stamped beyond the
+// maximum clock offset since the node started.
+func (d *Delegate) ClockSkews() uint64 {
+	d.skewed.mu.Lock()
+	defer d.skewed.mu.Unlock()
+	return d.skewed.total
+}
+
+// deferSkewed keeps an entry stamped at ts, beyond the maximum clock
+// offset, and merges it once the local clock is close enough.
+func (d *Delegate) deferSkewed(key string, value []byte, ts hlc.Timestamp, err error) {
+	wait := d.clock.Until(ts)
+	d.logger.Warn("entry stamped beyond the maximum clock offset, merging it later",
+		"key", key, "in", wait, "error", err)
+
+	d.skewed.mu.Lock()
+	defer d.skewed.mu.Unlock()
+	d.skewed.total++
+	if d.skewed.entries == nil {
+		d.skewed.entries = make(map[string][]byte)
+	}
+	if _, ok := d.skewed.entries[key]; !ok && len(d.skewed.entries) == maxSkewed {
+		return
+	}
+	d.skewed.entries[key] = append([]byte{}, value...)
+	time.AfterFunc(wait, func() {
+		d.enqueue(func() error {
+			d.mergeSkewed(key)
+			return nil
+		})
+	})
+}
+
+// mergeSkewed merges the entry kept for key by deferSkewed, if any.
+func (d *Delegate) mergeSkewed(key string) {
+	d.skewed.mu.Lock()
+	value, ok := d.skewed.entries[key]
+	delete(d.skewed.entries, key)
+	d.skewed.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	start := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+	if _, ok := d.stale(key, value); ok {
+		return
+	}
+	var applied int
+	if d.mergeEntry(key, value) {
+		applied = 1
+	}
+	d.observer.Merged(SourcePushPull, 1, applied, time.Since(start))
+}
@@ -0,0 +1,470 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Anti-entropy works in rounds so that the cost of a push/pull follows the
+// divergence between two nodes rather than the size of the dataset:
+//
+//  1. LocalState only carries a summary: one hash per bucket of keys.
+//  2. Both nodes receive the summary of the other. The one with the
+//     smaller name compares it with its own summary and sends the per-key
+//     digests of the buckets that differ straight to the remote node.
+//  3. The remote node answers with the entries the receiver is missing or
+//     has a different copy of, and asks for the ones it needs itself.
+//
+// Steps 2 and 3 travel as reliable user messages handled by NotifyMsg.
+
+// ProtocolVersion is the version of the replication protocol below, shared
+// with peers in the node metadata.
+const ProtocolVersion = 1
+
+const (
+	// numBuckets is the number of key buckets hashed into a state summary.
+	numBuckets = 64
+
+	// maxKeysPerMsg bounds how many keys travel in one digest, pull or entries message.
+	maxKeysPerMsg = 256
+)
+
+// message types, sent as the first byte of a user message
+const (
+	msgDigest byte = iota + 1
+	msgPull
+	msgEntries
+	msgPut
+)
+
+type (
+	// stateSummary is the push/pull payload returned by LocalState.
+	stateSummary struct {
+		Node    string
+		Count   int
+		Buckets []uint64
+	}
+
+	// digestMsg carries the hash of every key the sender holds in Buckets.
+	digestMsg struct {
+		From    string
+		Buckets []int
+		Digests map[string]uint64
+	}
+
+	// pullMsg asks the receiver to send the listed keys back.
+	pullMsg struct {
+		From string
+		Keys []string
+	}
+
+	// entriesMsg carries full values to merge.
+	entriesMsg struct {
+		From    string
+		Entries map[string][]byte
+	}
+)
+
+func bucketOf(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % numBuckets)
+}
+
+func digestOf(key string, value []byte) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write(value)
+	return h.Sum64()
+}
+
+// summary hashes the local state into buckets. Digests are XORed so the
+// result does not depend on iteration order.
+func (d *Delegate) summary() (stateSummary, error) {
+	s := stateSummary{
+		Node:    d.localName(),
+		Buckets: make([]uint64, numBuckets),
+	}
+	err := d.iterate(func(key string, value []byte) error {
+		s.Buckets[bucketOf(key)] ^= digestOf(key, value)
+		s.Count++
+		return nil
+	})
+	return s, err
+}
+
+// digests returns the per-key digests of the local keys falling in buckets.
+func (d *Delegate) digests(buckets []int) (map[string]uint64, error) {
+	wanted := make(map[int]bool, len(buckets))
+	for _, b := range buckets {
+		wanted[b] = true
+	}
+	digests := make(map[string]uint64)
+	err := d.iterate(func(key string, value []byte) error {
+		if wanted[bucketOf(key)] {
+			digests[key] = digestOf(key, value)
+		}
+		return nil
+	})
+	return digests, err
+}
+
+// entries loads the local values of keys, skipping the ones that are gone
+// unless they were forgotten recently.
+func (d *Delegate) entries(keys []string) (map[string][]byte, error) {
+	data := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if IsLocal(key) {
+			continue
+		}
+		b, err := d.backend.Get(key)
+		if err == ErrKeyNotFound {
+			if forgotten, ok := d.forgottenCopy(key); ok {
+				data[key] = forgotten
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		data[key] = b
+	}
+	return data, nil
+}
+
+// handleDigest compares the remote digests with the local ones, sends back
+// what the remote side is missing and pulls what this node is missing.
+func (d *Delegate) handleDigest(msg digestMsg) error {
+	d.mu.Lock()
+	local, err := d.digests(msg.Buckets)
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+
+	var push, pull []string
+	for key, h := range local {
+		if rh, ok := msg.Digests[key]; !ok || rh != h {
+			push = append(push, key)
+		}
+	}
+	for key, rh := range msg.Digests {
+		if h, ok := local[key]; !ok || rh != h {
+			pull = append(pull, key)
+		}
+		if _, ok := local[key]; !ok {
+			// tell the remote side about the events this node is done with,
+			// a newer copy it holds is still pulled
+			if _, ok := d.forgottenCopy(key); ok {
+				push = append(push, key)
+			}
+		}
+	}
+	data, err := d.entries(push)
+	if err == nil {
+		// the remote side is done with the events committed everywhere it
+		// lacks, and so is this node
+		for key, value := range data {
+			if _, ok := msg.Digests[key]; !ok && done(value) {
+				d.dropDone(key, value)
+				delete(data, key)
+			}
+		}
+	}
+	d.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		err = d.sendEntries(msg.From, data)
+		if err != nil {
+			return err
+		}
+		d.dropSent(data)
+	}
+	sort.Strings(pull)
+	for len(pull) > 0 {
+		n := len(pull)
+		if n > maxKeysPerMsg {
+			n = maxKeysPerMsg
+		}
+		err = d.send(msg.From, msgPull, pullMsg{From: d.localName(), Keys: pull[:n]})
+		if err != nil {
+			return err
+		}
+		pull = pull[n:]
+	}
+	return nil
+}
+
+// handlePull answers a pull request with the local copy of the keys.
+func (d *Delegate) handlePull(msg pullMsg) error {
+	d.mu.Lock()
+	data, err := d.entries(msg.Keys)
+	d.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	err = d.sendEntries(msg.From, data)
+	if err != nil {
+		return err
+	}
+	d.dropSent(data)
+	return nil
+}
+
+// done reports whether value is an event every region committed, which
+// peers delete when they merge it.
+func done(value []byte) bool {
+	var v V
+	return json.Unmarshal(value, &v) == nil && v.Meta.ToDelete
+}
+
+// dropSent deletes the events committed everywhere that were sent to a
+// peer, which deletes them too, unless they changed since. Otherwise the
+// last copy of an event would only go once a peer holds nothing at all.
+func (d *Delegate) dropSent(sent map[string][]byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+	for key, value := range sent {
+		if done(value) {
+			d.dropDone(key, value)
+		}
+	}
+}
+
+// dropDone deletes the local copy of key if it is still value. It must be
+// called with d.mu held.
+func (d *Delegate) dropDone(key string, value []byte) {
+	b, err := d.backend.Get(key)
+	if err != nil || !bytes.Equal(b, value) {
+		return
+	}
+	err = d.forget(key, value)
+	if err != nil {
+		d.logger.Error("failed to delete committed event", "key", key, "error", err)
+		return
+	}
+	d.logger.Debug("deleted event committed by every region", "key", key)
+}
+
+// handleEntries merges values sent by a peer.
+func (d *Delegate) handleEntries(msg entriesMsg) {
+	start := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+
+	var applied int
+	back := make(map[string][]byte)
+	for key, value := range msg.Entries {
+		if forgotten, ok := d.stale(key, value); ok {
+			back[key] = forgotten
+			continue
+		}
+		if d.mergeEntry(key, value) {
+			applied++
+		}
+	}
+	d.observer.Merged(SourcePushPull, len(msg.Entries), applied, time.Since(start))
+	d.logger.Debug("merged entries", "peer", msg.From, "entries", len(msg.Entries), "applied", applied)
+	if len(back) > 0 {
+		// the peer still holds events this node is done with
+		d.enqueue(func() error { return d.sendEntries(msg.From, back) })
+	}
+}
+
+// sendDigests sends the local digests of buckets to node, splitting them
+// over several messages when there are many keys.
+func (d *Delegate) sendDigests(node string, buckets []int) error {
+	d.mu.Lock()
+	digests, err := d.digests(buckets)
+	d.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// group per bucket so that every message covers whole buckets
+	perBucket := make(map[int]map[string]uint64)
+	for key, h := range digests {
+		b := bucketOf(key)
+		if perBucket[b] == nil {
+			perBucket[b] = make(map[string]uint64)
+		}
+		perBucket[b][key] = h
+	}
+
+	msg := digestMsg{From: d.localName(), Digests: make(map[string]uint64)}
+	for i, b := range buckets {
+		msg.Buckets = append(msg.Buckets, b)
+		for key, h := range perBucket[b] {
+			msg.Digests[key] = h
+		}
+		if len(msg.Digests) >= maxKeysPerMsg || i == len(buckets)-1 {
+			err = d.send(node, msgDigest, msg)
+			if err != nil {
+				return err
+			}
+			msg = digestMsg{From: d.localName(), Digests: make(map[string]uint64)}
+		}
+	}
+	return nil
+}
+
+// sendEntries sends data to node in batches of at most maxKeysPerMsg values.
+func (d *Delegate) sendEntries(node string, data map[string][]byte) error {
+	msg := entriesMsg{From: d.localName(), Entries: make(map[string][]byte)}
+	for key, value := range data {
+		msg.Entries[key] = value
+		if len(msg.Entries) >= maxKeysPerMsg {
+			err := d.send(node, msgEntries, msg)
+			if err != nil {
+				return err
+			}
+			msg.Entries = make(map[string][]byte)
+		}
+	}
+	if len(msg.Entries) == 0 {
+		return nil
+	}
+	return d.send(node, msgEntries, msg)
+}
+
+// send gob-encodes payload behind its message type and delivers it reliably to node.
+func (d *Delegate) send(node string, msgType byte, payload interface{}) error {
+	ml, _ := d.cluster()
+	if ml == nil {
+		return fmt.Errorf("storage: not attached to a cluster")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(msgType)
+	err := gob.NewEncoder(&buf).Encode(payload)
+	if err != nil {
+		return err
+	}
+	m, ok := d.peers.get(node)
+	if !ok {
+		return fmt.Errorf("storage: unknown node %s", node)
+	}
+	return ml.SendReliable(&m, buf.Bytes())
+}
+
+// purgeDeleted drops every local event already marked for deletion.
+func (d *Delegate) purgeDeleted() error {
+	toDelete := make(map[string][]byte)
+	err := d.iterate(func(key string, val []byte) error {
+		var v V
+		if err := json.Unmarshal(val, &v); err != nil {
+			return nil
+		}
+		if v.Meta.ToDelete {
+			toDelete[key] = append([]byte{}, val...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for key, val := range toDelete {
+		err = d.forget(key, val)
+		if err != nil {
+			d.logger.Error("failed to purge deleted event", "key", key, "error", err)
+		}
+	}
+	return nil
+}
+
+// pushDone sends events every region committed to each live peer, which
+// deletes them, then drops them here unless a peer could not be reached.
+func (d *Delegate) pushDone(done map[string][]byte) error {
+	var result *multierror.Error
+	for _, peer := range d.peers.names() {
+		if peer == d.localName() {
+			continue
+		}
+		err := d.sendEntries(peer, done)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("push committed events to %s: %w", peer, err))
+		}
+	}
+	if result != nil {
+		return result
+	}
+	d.dropSent(done)
+	return nil
+}
+
+// Flush pushes every event still waiting for other regions to each live
+// peer, so writes accepted by this node survive it leaving the cluster.
+// It returns how many events were sent and the errors met along the way;
+// peers that could not be reached do not stop the others from being served.
+func (d *Delegate) Flush(ctx context.Context) (int, error) {
+	if ml, _ := d.cluster(); ml == nil {
+		return 0, nil
+	}
+	var peers []string
+	for _, name := range d.peers.names() {
+		if name != d.localName() {
+			peers = append(peers, name)
+		}
+	}
+	return d.PushPending(ctx, peers...)
+}
+
+// PushPending sends every event still waiting for other regions to the
+// given peers, e.g. to a member that just joined so that it commits them
+// without waiting for the next push/pull.
+func (d *Delegate) PushPending(ctx context.Context, peers ...string) (int, error) {
+	if ml, _ := d.cluster(); ml == nil || len(peers) == 0 {
+		return 0, nil
+	}
+
+	regions := d.Regions()
+	d.mu.Lock()
+	pending := make(map[string][]byte)
+	err := d.iterate(func(key string, val []byte) error {
+		var v V
+		if err := json.Unmarshal(val, &v); err != nil {
+			return nil
+		}
+		if !complete(v.Meta, regions) {
+			pending[key] = append([]byte{}, val...)
+		}
+		return nil
+	})
+	d.mu.Unlock()
+	if err != nil || len(pending) == 0 {
+		return 0, err
+	}
+
+	var result *multierror.Error
+	for _, peer := range peers {
+		if err := ctx.Err(); err != nil {
+			return len(pending), multierror.Append(result, err).ErrorOrNil()
+		}
+		err = d.sendEntries(peer, pending)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("push to %s: %w", peer, err))
+		}
+	}
+	return len(pending), result.ErrorOrNil()
+}
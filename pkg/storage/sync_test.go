@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/memberlist"
+)
+
+// network connects test delegates, delivering their messages by hand and
+// counting what they carry.
+type network struct {
+	nodes map[string]*Delegate
+
+	digests int // digest messages
+	keys    int // keys in digests
+	entries int // values sent
+}
+
+// endpoint is the gossip of a delegate of network.
+type endpoint struct {
+	net  *network
+	name string
+}
+
+func (e endpoint) LocalNode() *memberlist.Node { return &memberlist.Node{Name: e.name} }
+func (e endpoint) NumMembers() int             { return len(e.net.nodes) }
+
+func (e endpoint) SendReliable(to *memberlist.Node, msg []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(msg[1:]))
+	switch msg[0] {
+	case msgDigest:
+		var m digestMsg
+		if err := dec.Decode(&m); err != nil {
+			return err
+		}
+		e.net.digests++
+		e.net.keys += len(m.Digests)
+	case msgEntries:
+		var m entriesMsg
+		if err := dec.Decode(&m); err != nil {
+			return err
+		}
+		e.net.entries += len(m.Entries)
+	}
+	e.net.nodes[to.Name].NotifyMsg(msg)
+	return nil
+}
+
+func newNetwork(names ...string) *network {
+	n := &network{nodes: make(map[string]*Delegate)}
+	for i, name := range names {
+		d := newTestDelegate(uint(i + 1))
+		d.attach(endpoint{net: n, name: name})
+		n.nodes[name] = d
+	}
+	for _, d := range n.nodes {
+		for _, name := range names {
+			d.PeerAlive(&memberlist.Node{Name: name})
+		}
+	}
+	return n
+}
+
+// pushPull runs a push/pull between two nodes and the exchanges it starts.
+func (n *network) pushPull(a, b string) {
+	sa := n.nodes[a].LocalState(false)
+	sb := n.nodes[b].LocalState(false)
+	n.nodes[a].MergeRemoteState(sb, false)
+	n.nodes[b].MergeRemoteState(sa, false)
+	for queued := true; queued; {
+		queued = false
+		for _, d := range n.nodes {
+			select {
+			case fn := <-d.replies:
+				queued = true
+				if err := fn(); err != nil {
+					panic(err)
+				}
+			default:
+			}
+		}
+	}
+}
+
+func TestSyncTrafficFollowsDivergence(t *testing.T) {
+	const shared = 2000
+	for _, diverging := range []int{0, 1, 10, 100} {
+		t.Run(fmt.Sprint(diverging), func(t *testing.T) {
+			n := newNetwork("a", "b")
+			a, b := n.nodes["a"], n.nodes["b"]
+			for i := 0; i < shared; i++ {
+				v, err := a.Write(V{ID: fmt.Sprint("shared-", i), Data: []byte("v")}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				raw, _ := a.backend.Get(v.ID)
+				b.backend.Put(v.ID, raw)
+			}
+			for i := 0; i < diverging; i++ {
+				id := fmt.Sprint("new-", i)
+				// half the new events on each side
+				d := a
+				if i%2 == 1 {
+					d = b
+				}
+				if _, err := d.Write(V{ID: id, Data: []byte("v")}, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			n.pushPull("a", "b")
+			for i := 0; i < diverging; i++ {
+				id := fmt.Sprint("new-", i)
+				for name, d := range n.nodes {
+					if _, err := d.load(id); err != nil {
+						t.Fatalf("%s: %s: %v", name, id, err)
+					}
+				}
+			}
+			if n.entries != diverging {
+				t.Errorf("%d values sent for %d new events, want one each", n.entries, diverging)
+			}
+			// a digest covers whole buckets, each holding about
+			// shared/numBuckets keys
+			if max := diverging * 3 * shared / numBuckets; n.keys > max {
+				t.Errorf("digests carried %d keys, want at most %d", n.keys, max)
+			}
+			if diverging == 0 && n.digests != 0 {
+				t.Errorf("%d digests sent between nodes in sync", n.digests)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Delete replaces the event stored under key with a tombstone stamped with
+// a newer timestamp than the current value, and gossips it like any other write.
+// A tombstone is written even if the key is unknown locally so that it
+// wins over a put still travelling through the cluster.
+func (d *Delegate) Delete(key string, sourceRegion int, version int, now time.Time) (V, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	v, err := d.load(key)
+	if err != nil && err != ErrKeyNotFound {
+		return V{}, err
+	}
+	if err == nil && v.Deleted() {
+		return v, nil
+	}
+
+	if version <= v.Meta.Version {
+		version = v.Meta.Version + 1
+	}
+	tombstone := V{
+		ID:         key,
+		ActionName: v.ActionName,
+		Meta: Meta{
+			Version:      version,
+			SVCCode:      v.Meta.SVCCode,
+			SourceRegion: sourceRegion,
+			Tombstone:    true,
+			DeletedAt:    now.UnixNano(),
+		},
+	}
+	return d.write(tombstone, nil)
+}
+
+// DefaultTombstoneGracePeriod is how long a tombstone acknowledged by every
+// region is kept unless SetTombstoneGracePeriod says otherwise.
+const DefaultTombstoneGracePeriod = time.Hour
+
+// SetTombstoneGracePeriod sets how long a tombstone acknowledged by every
+// region is kept before CollectTombstones drops it. It must be called
+// before the delegate is used.
+func (d *Delegate) SetTombstoneGracePeriod(grace time.Duration) {
+	d.tombstoneGrace = grace
+}
+
+// expired reports whether v is a tombstone every region has acknowledged
+// for longer than the grace period.
+func (d *Delegate) expired(v V, regions []uint, now time.Time) bool {
+	return v.Deleted() && complete(v.Meta, regions) &&
+		now.Sub(time.Unix(0, v.Meta.DeletedAt)) >= d.tombstoneGrace
+}
+
+// CollectTombstones removes the tombstones every region has acknowledged
+// for longer than the grace period and returns how many were dropped.
+// Peers that have not collected them yet may still send them; they are
+// not stored again, see mergeEntry.
+func (d *Delegate) CollectTombstones(now time.Time) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return 0, nil
+	}
+
+	regions := d.Regions()
+	var expired []string
+	err := d.iterate(func(key string, val []byte) error {
+		var v V
+		if err := json.Unmarshal(val, &v); err != nil {
+			return nil
+		}
+		if d.expired(v, regions, now) {
+			expired = append(expired, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range expired {
+		err = d.backend.Del(key)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(expired) > 0 {
+		d.observer.TombstonesCollected(len(expired))
+		d.logger.Info("collected tombstones", "count", len(expired))
+	}
+	return len(expired), nil
+}
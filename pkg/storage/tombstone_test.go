@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTombstoneGracePeriod(t *testing.T) {
+	const grace = time.Minute
+	c := newCluster()
+	for _, d := range c {
+		d.SetTombstoneGracePeriod(grace)
+	}
+	deletedAt := time.Now().Add(-2 * grace)
+	if _, err := c[1].Delete("k", 1, 0, deletedAt); err != nil {
+		t.Fatal(err)
+	}
+	// collected nowhere until every region acknowledged it
+	if n, _ := c[1].CollectTombstones(time.Now()); n != 0 {
+		t.Fatalf("collected %d tombstones acknowledged by one region", n)
+	}
+	c.send(t, 1, 2, "k")
+	c.send(t, 2, 3, "k")
+	c.send(t, 3, 1, "k")
+	c.send(t, 3, 2, "k")
+	stale, _ := c[2].backend.Get("k")
+
+	// kept during the grace period
+	if n, _ := c[1].CollectTombstones(deletedAt.Add(grace / 2)); n != 0 {
+		t.Fatalf("collected %d tombstones within the grace period", n)
+	}
+	if n, _ := c[1].CollectTombstones(time.Now()); n != 1 {
+		t.Fatalf("collected %d tombstones, want 1", n)
+	}
+	if _, ok := c.load(t, 1, "k"); ok {
+		t.Fatal("region 1 still holds the tombstone")
+	}
+
+	// a peer that has not collected it yet does not bring it back
+	c[1].mergeEntry("k", stale)
+	if _, ok := c.load(t, 1, "k"); ok {
+		t.Fatal("a collected tombstone was stored again")
+	}
+
+	// a tombstone still within its grace period is stored
+	if _, err := c[2].Delete("j", 2, 0, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	c.send(t, 2, 1, "j")
+	if _, ok := c.load(t, 1, "j"); !ok {
+		t.Fatal("a recent tombstone was not stored")
+	}
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"sort"
+)
+
+// Ordering is the causal relation between two version vectors.
+type Ordering int
+
+const (
+	Equal Ordering = iota
+	Before
+	After
+	Concurrent
+)
+
+// VersionVector counts the writes each region has made to an event.
+// Writes accepted by the same region are ordered by that region; the vector
+// detects writes made concurrently in different regions.
+type VersionVector map[uint]uint64
+
+// Compare returns how vv relates to o.
+func (vv VersionVector) Compare(o VersionVector) Ordering {
+	var less, greater bool
+	for region, c := range vv {
+		if c > o[region] {
+			greater = true
+		} else if c < o[region] {
+			less = true
+		}
+	}
+	for region, c := range o {
+		if _, ok := vv[region]; !ok && c > 0 {
+			less = true
+		}
+	}
+	switch {
+	case less && greater:
+		return Concurrent
+	case less:
+		return Before
+	case greater:
+		return After
+	}
+	return Equal
+}
+
+// Merge returns the pairwise maximum of vv and o.
+func (vv VersionVector) Merge(o VersionVector) VersionVector {
+	out := make(VersionVector, len(vv))
+	for region, c := range vv {
+		out[region] = c
+	}
+	for region, c := range o {
+		if c > out[region] {
+			out[region] = c
+		}
+	}
+	return out
+}
+
+// versions returns v and its siblings as a flat list of versions.
+func (v V) versions() []V {
+	top := v
+	top.Siblings = nil
+	return append([]V{top}, v.Siblings...)
+}
+
+// Context returns the merged vector of v and its siblings; a write
+// carrying it supersedes all of them.
+func (v V) Context() VersionVector {
+	ctx := VersionVector{}
+	for _, version := range v.versions() {
+		ctx = ctx.Merge(version.Meta.Vector)
+	}
+	return ctx
+}
+
+// reconcile merges the local and remote versions of an event. Versions
+// causally dominated by another one are dropped; of two versions with the
+// same vector, the last writer wins and keeps the regions that committed
+// either. The survivors are returned latest timestamp first.
+func reconcile(local, remote []V) []V {
+	var out []V
+	add := func(v V) {
+		for i := 0; i < len(out); i++ {
+			switch v.Meta.Vector.Compare(out[i].Meta.Vector) {
+			case Before:
+				return
+			case After:
+				out = append(out[:i], out[i+1:]...)
+				i--
+			case Equal:
+				commits := out[i].Meta.CommitedRegions.Merge(v.Meta.CommitedRegions)
+				if v.Meta.Wins(out[i].Meta) {
+					out[i] = v
+				}
+				out[i].Meta.CommitedRegions = commits
+				return
+			}
+		}
+		out = append(out, v)
+	}
+	for _, v := range local {
+		add(v)
+	}
+	for _, v := range remote {
+		add(v)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return !out[j].Meta.Wins(out[i].Meta)
+	})
+	return out
+}
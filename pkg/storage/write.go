@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"encoding/json"
+)
+
+// Write stores v as a new local version of its event, stamped with the
+// next timestamp, and gossips it. The new version supersedes every local
+// version covered by context; a nil context supersedes everything stored
+// locally. Versions the context does not cover are kept as siblings.
+func (d *Delegate) Write(v V, context VersionVector) (V, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.write(v, context)
+}
+
+func (d *Delegate) write(v V, context VersionVector) (V, error) {
+	if IsLocal(v.ID) {
+		return V{}, ErrReservedKey
+	}
+	existing, err := d.load(v.ID)
+	if err != nil && err != ErrKeyNotFound {
+		return V{}, err
+	}
+	var local []V
+	if err == nil {
+		local = existing.versions()
+	}
+	if context == nil {
+		context = existing.Context()
+	}
+
+	// count this write after every write of this region seen locally so
+	// that it never collides with one of them
+	var own uint64
+	for _, version := range local {
+		if c := version.Meta.Vector[d.regionID]; c > own {
+			own = c
+		}
+	}
+	if c := context[d.regionID]; c > own {
+		own = c
+	}
+	v.Siblings = nil
+	v.Meta.Vector = context.Merge(VersionVector{d.regionID: own + 1})
+	v.Meta.HLC = d.clock.Now()
+	v.Meta.CommitedRegions = CommitSet{d.regionID: true}
+
+	versions := reconcile(local, []V{v})
+	result := versions[0]
+	result.Siblings = versions[1:]
+	val, err := json.Marshal(result)
+	if err != nil {
+		return V{}, err
+	}
+	err = d.backend.Put(v.ID, val)
+	if err != nil {
+		return V{}, err
+	}
+	d.broadcast(v.ID, val)
+	return result, nil
+}
+
+// load reads and decodes the event stored under key.
+func (d *Delegate) load(key string) (V, error) {
+	b, err := d.backend.Get(key)
+	if err != nil {
+		return V{}, err
+	}
+	var v V
+	err = json.Unmarshal(b, &v)
+	return v, err
+}
@@ -36,6 +36,22 @@ type EventReplicatorService interface {
 	Put(context.Context, *PutEventRequest) (*Event, error)
 
 	Get(context.Context, *GetEventRequest) (*Event, error)
+
+	Delete(context.Context, *DeleteEventRequest) (*Event, error)
+
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+
+	Resolve(context.Context, *ResolveEventRequest) (*Event, error)
+
+	GetReplicationStatus(context.Context, *GetReplicationStatusRequest) (*ReplicationStatus, error)
+
+	ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error)
+
+	GetRegions(context.Context, *GetRegionsRequest) (*RegionRegistry, error)
+
+	UpdateRegions(context.Context, *UpdateRegionsRequest) (*RegionRegistry, error)
+
+	ListQuarantined(context.Context, *ListQuarantinedRequest) (*ListQuarantinedResponse, error)
 }
 
 // ======================================
@@ -44,7 +60,7 @@ type EventReplicatorService interface {
 
 type eventReplicatorServiceProtobufClient struct {
 	client      HTTPClient
-	urls        [2]string
+	urls        [10]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -72,9 +88,17 @@ func NewEventReplicatorServiceProtobufClient(baseURL string, client HTTPClient,
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "replicator", "EventReplicatorService")
-	urls := [2]string{
+	urls := [10]string{
 		serviceURL + "Put",
 		serviceURL + "Get",
+		serviceURL + "Delete",
+		serviceURL + "ListEvents",
+		serviceURL + "Resolve",
+		serviceURL + "GetReplicationStatus",
+		serviceURL + "ListMembers",
+		serviceURL + "GetRegions",
+		serviceURL + "UpdateRegions",
+		serviceURL + "ListQuarantined",
 	}
 
 	return &eventReplicatorServiceProtobufClient{
@@ -177,67 +201,112 @@ func (c *eventReplicatorServiceProtobufClient) callGet(ctx context.Context, in *
 	return out, nil
 }
 
-// ==================================
-// EventReplicatorService JSON Client
-// ==================================
-
-type eventReplicatorServiceJSONClient struct {
-	client      HTTPClient
-	urls        [2]string
-	interceptor twirp.Interceptor
-	opts        twirp.ClientOptions
+func (c *eventReplicatorServiceProtobufClient) Delete(ctx context.Context, in *DeleteEventRequest) (*Event, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	caller := c.callDelete
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteEventRequest) (*Event, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteEventRequest) when calling interceptor")
+					}
+					return c.callDelete(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
 }
 
-// NewEventReplicatorServiceJSONClient creates a JSON client that implements the EventReplicatorService interface.
-// It communicates using JSON and can be configured with a custom HTTPClient.
-func NewEventReplicatorServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) EventReplicatorService {
-	if c, ok := client.(*http.Client); ok {
-		client = withoutRedirects(c)
+func (c *eventReplicatorServiceProtobufClient) callDelete(ctx context.Context, in *DeleteEventRequest) (*Event, error) {
+	out := new(Event)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
 
-	clientOpts := twirp.ClientOptions{}
-	for _, o := range opts {
-		o(&clientOpts)
-	}
+	callClientResponseReceived(ctx, c.opts.Hooks)
 
-	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
-	literalURLs := false
-	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
-	var pathPrefix string
-	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
-		pathPrefix = "/twirp" // default prefix
-	}
+	return out, nil
+}
 
-	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
-	serviceURL := sanitizeBaseURL(baseURL)
-	serviceURL += baseServicePath(pathPrefix, "replicator", "EventReplicatorService")
-	urls := [2]string{
-		serviceURL + "Put",
-		serviceURL + "Get",
+func (c *eventReplicatorServiceProtobufClient) ListEvents(ctx context.Context, in *ListEventsRequest) (*ListEventsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListEvents")
+	caller := c.callListEvents
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListEventsRequest) when calling interceptor")
+					}
+					return c.callListEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
 	}
+	return caller(ctx, in)
+}
 
-	return &eventReplicatorServiceJSONClient{
-		client:      client,
-		urls:        urls,
-		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
-		opts:        clientOpts,
+func (c *eventReplicatorServiceProtobufClient) callListEvents(ctx context.Context, in *ListEventsRequest) (*ListEventsResponse, error) {
+	out := new(ListEventsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
 }
 
-func (c *eventReplicatorServiceJSONClient) Put(ctx context.Context, in *PutEventRequest) (*Event, error) {
+func (c *eventReplicatorServiceProtobufClient) Resolve(ctx context.Context, in *ResolveEventRequest) (*Event, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "replicator")
 	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
-	ctx = ctxsetters.WithMethodName(ctx, "Put")
-	caller := c.callPut
+	ctx = ctxsetters.WithMethodName(ctx, "Resolve")
+	caller := c.callResolve
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *PutEventRequest) (*Event, error) {
+		caller = func(ctx context.Context, req *ResolveEventRequest) (*Event, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*PutEventRequest)
+					typedReq, ok := req.(*ResolveEventRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*PutEventRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ResolveEventRequest) when calling interceptor")
 					}
-					return c.callPut(ctx, typedReq)
+					return c.callResolve(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
@@ -253,9 +322,193 @@ func (c *eventReplicatorServiceJSONClient) Put(ctx context.Context, in *PutEvent
 	return caller(ctx, in)
 }
 
-func (c *eventReplicatorServiceJSONClient) callPut(ctx context.Context, in *PutEventRequest) (*Event, error) {
+func (c *eventReplicatorServiceProtobufClient) callResolve(ctx context.Context, in *ResolveEventRequest) (*Event, error) {
 	out := new(Event)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceProtobufClient) GetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetReplicationStatus")
+	caller := c.callGetReplicationStatus
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetReplicationStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetReplicationStatusRequest) when calling interceptor")
+					}
+					return c.callGetReplicationStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplicationStatus)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplicationStatus) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceProtobufClient) callGetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+	out := new(ReplicationStatus)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceProtobufClient) ListMembers(ctx context.Context, in *ListMembersRequest) (*ListMembersResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListMembers")
+	caller := c.callListMembers
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListMembersRequest) (*ListMembersResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListMembersRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListMembersRequest) when calling interceptor")
+					}
+					return c.callListMembers(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListMembersResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListMembersResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceProtobufClient) callListMembers(ctx context.Context, in *ListMembersRequest) (*ListMembersResponse, error) {
+	out := new(ListMembersResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceProtobufClient) GetRegions(ctx context.Context, in *GetRegionsRequest) (*RegionRegistry, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetRegions")
+	caller := c.callGetRegions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetRegionsRequest) (*RegionRegistry, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetRegionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetRegionsRequest) when calling interceptor")
+					}
+					return c.callGetRegions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegionRegistry)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceProtobufClient) callGetRegions(ctx context.Context, in *GetRegionsRequest) (*RegionRegistry, error) {
+	out := new(RegionRegistry)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceProtobufClient) UpdateRegions(ctx context.Context, in *UpdateRegionsRequest) (*RegionRegistry, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateRegions")
+	caller := c.callUpdateRegions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpdateRegionsRequest) (*RegionRegistry, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpdateRegionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpdateRegionsRequest) when calling interceptor")
+					}
+					return c.callUpdateRegions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegionRegistry)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceProtobufClient) callUpdateRegions(ctx context.Context, in *UpdateRegionsRequest) (*RegionRegistry, error) {
+	out := new(RegionRegistry)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -265,168 +518,2147 @@ func (c *eventReplicatorServiceJSONClient) callPut(ctx context.Context, in *PutE
 		return nil, err
 	}
 
-	callClientResponseReceived(ctx, c.opts.Hooks)
-
-	return out, nil
-}
-
-func (c *eventReplicatorServiceJSONClient) Get(ctx context.Context, in *GetEventRequest) (*Event, error) {
-	ctx = ctxsetters.WithPackageName(ctx, "replicator")
-	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
-	ctx = ctxsetters.WithMethodName(ctx, "Get")
-	caller := c.callGet
-	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *GetEventRequest) (*Event, error) {
-			resp, err := c.interceptor(
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceProtobufClient) ListQuarantined(ctx context.Context, in *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuarantined")
+	caller := c.callListQuarantined
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListQuarantinedRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuarantinedRequest) when calling interceptor")
+					}
+					return c.callListQuarantined(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListQuarantinedResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuarantinedResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceProtobufClient) callListQuarantined(ctx context.Context, in *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
+	out := new(ListQuarantinedResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ==================================
+// EventReplicatorService JSON Client
+// ==================================
+
+type eventReplicatorServiceJSONClient struct {
+	client      HTTPClient
+	urls        [10]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewEventReplicatorServiceJSONClient creates a JSON client that implements the EventReplicatorService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewEventReplicatorServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) EventReplicatorService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "replicator", "EventReplicatorService")
+	urls := [10]string{
+		serviceURL + "Put",
+		serviceURL + "Get",
+		serviceURL + "Delete",
+		serviceURL + "ListEvents",
+		serviceURL + "Resolve",
+		serviceURL + "GetReplicationStatus",
+		serviceURL + "ListMembers",
+		serviceURL + "GetRegions",
+		serviceURL + "UpdateRegions",
+		serviceURL + "ListQuarantined",
+	}
+
+	return &eventReplicatorServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *eventReplicatorServiceJSONClient) Put(ctx context.Context, in *PutEventRequest) (*Event, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "Put")
+	caller := c.callPut
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PutEventRequest) (*Event, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PutEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PutEventRequest) when calling interceptor")
+					}
+					return c.callPut(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callPut(ctx context.Context, in *PutEventRequest) (*Event, error) {
+	out := new(Event)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) Get(ctx context.Context, in *GetEventRequest) (*Event, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "Get")
+	caller := c.callGet
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetEventRequest) (*Event, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetEventRequest) when calling interceptor")
+					}
+					return c.callGet(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callGet(ctx context.Context, in *GetEventRequest) (*Event, error) {
+	out := new(Event)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) Delete(ctx context.Context, in *DeleteEventRequest) (*Event, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	caller := c.callDelete
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteEventRequest) (*Event, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteEventRequest) when calling interceptor")
+					}
+					return c.callDelete(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callDelete(ctx context.Context, in *DeleteEventRequest) (*Event, error) {
+	out := new(Event)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) ListEvents(ctx context.Context, in *ListEventsRequest) (*ListEventsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListEvents")
+	caller := c.callListEvents
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListEventsRequest) when calling interceptor")
+					}
+					return c.callListEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callListEvents(ctx context.Context, in *ListEventsRequest) (*ListEventsResponse, error) {
+	out := new(ListEventsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) Resolve(ctx context.Context, in *ResolveEventRequest) (*Event, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "Resolve")
+	caller := c.callResolve
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ResolveEventRequest) (*Event, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ResolveEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ResolveEventRequest) when calling interceptor")
+					}
+					return c.callResolve(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callResolve(ctx context.Context, in *ResolveEventRequest) (*Event, error) {
+	out := new(Event)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) GetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetReplicationStatus")
+	caller := c.callGetReplicationStatus
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetReplicationStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetReplicationStatusRequest) when calling interceptor")
+					}
+					return c.callGetReplicationStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplicationStatus)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplicationStatus) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callGetReplicationStatus(ctx context.Context, in *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+	out := new(ReplicationStatus)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) ListMembers(ctx context.Context, in *ListMembersRequest) (*ListMembersResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListMembers")
+	caller := c.callListMembers
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListMembersRequest) (*ListMembersResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListMembersRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListMembersRequest) when calling interceptor")
+					}
+					return c.callListMembers(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListMembersResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListMembersResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callListMembers(ctx context.Context, in *ListMembersRequest) (*ListMembersResponse, error) {
+	out := new(ListMembersResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) GetRegions(ctx context.Context, in *GetRegionsRequest) (*RegionRegistry, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetRegions")
+	caller := c.callGetRegions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetRegionsRequest) (*RegionRegistry, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetRegionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetRegionsRequest) when calling interceptor")
+					}
+					return c.callGetRegions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegionRegistry)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callGetRegions(ctx context.Context, in *GetRegionsRequest) (*RegionRegistry, error) {
+	out := new(RegionRegistry)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) UpdateRegions(ctx context.Context, in *UpdateRegionsRequest) (*RegionRegistry, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateRegions")
+	caller := c.callUpdateRegions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpdateRegionsRequest) (*RegionRegistry, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpdateRegionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpdateRegionsRequest) when calling interceptor")
+					}
+					return c.callUpdateRegions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegionRegistry)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callUpdateRegions(ctx context.Context, in *UpdateRegionsRequest) (*RegionRegistry, error) {
+	out := new(RegionRegistry)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *eventReplicatorServiceJSONClient) ListQuarantined(ctx context.Context, in *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuarantined")
+	caller := c.callListQuarantined
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListQuarantinedRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuarantinedRequest) when calling interceptor")
+					}
+					return c.callListQuarantined(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListQuarantinedResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuarantinedResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *eventReplicatorServiceJSONClient) callListQuarantined(ctx context.Context, in *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
+	out := new(ListQuarantinedResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// =====================================
+// EventReplicatorService Server Handler
+// =====================================
+
+type eventReplicatorServiceServer struct {
+	EventReplicatorService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewEventReplicatorServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewEventReplicatorServiceServer(svc EventReplicatorService, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &eventReplicatorServiceServer{
+		EventReplicatorService: svc,
+		hooks:                  serverOpts.Hooks,
+		interceptor:            twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:             pathPrefix,
+		jsonSkipDefaults:       jsonSkipDefaults,
+		jsonCamelCase:          jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *eventReplicatorServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *eventReplicatorServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// EventReplicatorServicePathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const EventReplicatorServicePathPrefix = "/twirp/replicator.EventReplicatorService/"
+
+func (s *eventReplicatorServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "replicator")
+	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "replicator.EventReplicatorService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "Put":
+		s.servePut(ctx, resp, req)
+		return
+	case "Get":
+		s.serveGet(ctx, resp, req)
+		return
+	case "Delete":
+		s.serveDelete(ctx, resp, req)
+		return
+	case "ListEvents":
+		s.serveListEvents(ctx, resp, req)
+		return
+	case "Resolve":
+		s.serveResolve(ctx, resp, req)
+		return
+	case "GetReplicationStatus":
+		s.serveGetReplicationStatus(ctx, resp, req)
+		return
+	case "ListMembers":
+		s.serveListMembers(ctx, resp, req)
+		return
+	case "GetRegions":
+		s.serveGetRegions(ctx, resp, req)
+		return
+	case "UpdateRegions":
+		s.serveUpdateRegions(ctx, resp, req)
+		return
+	case "ListQuarantined":
+		s.serveListQuarantined(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *eventReplicatorServiceServer) servePut(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePutJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePutProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) servePutJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Put")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(PutEventRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.Put
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PutEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PutEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PutEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Put(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Put. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) servePutProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Put")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(PutEventRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.EventReplicatorService.Put
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PutEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PutEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PutEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Put(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Put. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveGet(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) serveGetJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Get")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetEventRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.Get
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Get(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Get. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveGetProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Get")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetEventRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.EventReplicatorService.Get
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Get(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Get. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveDelete(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) serveDeleteJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeleteEventRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.Delete
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Delete(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Delete. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveDeleteProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeleteEventRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.EventReplicatorService.Delete
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Delete(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Delete. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveListEvents(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListEventsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListEventsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) serveListEventsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListEvents")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListEventsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.ListEvents
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListEventsRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.ListEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListEventsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListEventsResponse and nil error while calling ListEvents. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveListEventsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListEvents")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListEventsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.EventReplicatorService.ListEvents
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListEventsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListEventsRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.ListEvents(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListEventsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListEventsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListEventsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListEventsResponse and nil error while calling ListEvents. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveResolve(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveResolveJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveResolveProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) serveResolveJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Resolve")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ResolveEventRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.Resolve
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ResolveEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ResolveEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ResolveEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Resolve(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Resolve. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveResolveProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Resolve")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ResolveEventRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.EventReplicatorService.Resolve
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ResolveEventRequest) (*Event, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ResolveEventRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ResolveEventRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.Resolve(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*Event)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *Event
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Resolve. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveGetReplicationStatus(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetReplicationStatusJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetReplicationStatusProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) serveGetReplicationStatusJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetReplicationStatus")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetReplicationStatusRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.GetReplicationStatus
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetReplicationStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetReplicationStatusRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.GetReplicationStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplicationStatus)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplicationStatus) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ReplicationStatus
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ReplicationStatus and nil error while calling GetReplicationStatus. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveGetReplicationStatusProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetReplicationStatus")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetReplicationStatusRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.EventReplicatorService.GetReplicationStatus
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetReplicationStatusRequest) (*ReplicationStatus, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetReplicationStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetReplicationStatusRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.GetReplicationStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ReplicationStatus)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ReplicationStatus) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ReplicationStatus
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ReplicationStatus and nil error while calling GetReplicationStatus. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveListMembers(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListMembersJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListMembersProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) serveListMembersJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListMembers")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListMembersRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.ListMembers
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListMembersRequest) (*ListMembersResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListMembersRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListMembersRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.ListMembers(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListMembersResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListMembersResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListMembersResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListMembersResponse and nil error while calling ListMembers. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveListMembersProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListMembers")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListMembersRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.EventReplicatorService.ListMembers
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListMembersRequest) (*ListMembersResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListMembersRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListMembersRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.ListMembers(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListMembersResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListMembersResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListMembersResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListMembersResponse and nil error while calling ListMembers. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *eventReplicatorServiceServer) serveGetRegions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetRegionsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetRegionsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *eventReplicatorServiceServer) serveGetRegionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetRegions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetRegionsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.EventReplicatorService.GetRegions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetRegionsRequest) (*RegionRegistry, error) {
+			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*GetEventRequest)
+					typedReq, ok := req.(*GetRegionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*GetEventRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*GetRegionsRequest) when calling interceptor")
 					}
-					return c.callGet(ctx, typedReq)
+					return s.EventReplicatorService.GetRegions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*Event)
+				typedResp, ok := resp.(*RegionRegistry)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
 				}
 				return typedResp, err
 			}
 			return nil, err
 		}
 	}
-	return caller(ctx, in)
-}
 
-func (c *eventReplicatorServiceJSONClient) callGet(ctx context.Context, in *GetEventRequest) (*Event, error) {
-	out := new(Event)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	// Call service method
+	var respContent *RegionRegistry
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
 	if err != nil {
-		twerr, ok := err.(twirp.Error)
-		if !ok {
-			twerr = twirp.InternalErrorWith(err)
-		}
-		callClientError(ctx, c.opts.Hooks, twerr)
-		return nil, err
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RegionRegistry and nil error while calling GetRegions. nil responses are not supported"))
+		return
 	}
 
-	callClientResponseReceived(ctx, c.opts.Hooks)
-
-	return out, nil
-}
-
-// =====================================
-// EventReplicatorService Server Handler
-// =====================================
-
-type eventReplicatorServiceServer struct {
-	EventReplicatorService
-	interceptor      twirp.Interceptor
-	hooks            *twirp.ServerHooks
-	pathPrefix       string // prefix for routing
-	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
-	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
-}
-
-// NewEventReplicatorServiceServer builds a TwirpServer that can be used as an http.Handler to handle
-// HTTP requests that are routed to the right method in the provided svc implementation.
-// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
-func NewEventReplicatorServiceServer(svc EventReplicatorService, opts ...interface{}) TwirpServer {
-	serverOpts := newServerOpts(opts)
+	ctx = callResponsePrepared(ctx, s.hooks)
 
-	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
-	jsonSkipDefaults := false
-	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
-	jsonCamelCase := false
-	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
-	var pathPrefix string
-	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
-		pathPrefix = "/twirp" // default prefix
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
 	}
 
-	return &eventReplicatorServiceServer{
-		EventReplicatorService: svc,
-		hooks:                  serverOpts.Hooks,
-		interceptor:            twirp.ChainInterceptors(serverOpts.Interceptors...),
-		pathPrefix:             pathPrefix,
-		jsonSkipDefaults:       jsonSkipDefaults,
-		jsonCamelCase:          jsonCamelCase,
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
 	}
+	callResponseSent(ctx, s.hooks)
 }
 
-// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func (s *eventReplicatorServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
-	writeError(ctx, resp, err, s.hooks)
-}
+func (s *eventReplicatorServiceServer) serveGetRegionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetRegions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
 
-// handleRequestBodyError is used to handle error when the twirp server cannot read request
-func (s *eventReplicatorServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
-	if context.Canceled == ctx.Err() {
-		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	if context.DeadlineExceeded == ctx.Err() {
-		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+	reqContent := new(GetRegionsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
-	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
-}
 
-// EventReplicatorServicePathPrefix is a convenience constant that may identify URL paths.
-// Should be used with caution, it only matches routes generated by Twirp Go clients,
-// with the default "/twirp" prefix and default CamelCase service and method names.
-// More info: https://twitchtv.github.io/twirp/docs/routing.html
-const EventReplicatorServicePathPrefix = "/twirp/replicator.EventReplicatorService/"
+	handler := s.EventReplicatorService.GetRegions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetRegionsRequest) (*RegionRegistry, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetRegionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetRegionsRequest) when calling interceptor")
+					}
+					return s.EventReplicatorService.GetRegions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RegionRegistry)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
 
-func (s *eventReplicatorServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
-	ctx := req.Context()
-	ctx = ctxsetters.WithPackageName(ctx, "replicator")
-	ctx = ctxsetters.WithServiceName(ctx, "EventReplicatorService")
-	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+	// Call service method
+	var respContent *RegionRegistry
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
 
-	var err error
-	ctx, err = callRequestReceived(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
-
-	if req.Method != "POST" {
-		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RegionRegistry and nil error while calling GetRegions. nil responses are not supported"))
 		return
 	}
 
-	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
-	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
-	if pkgService != "replicator.EventReplicatorService" {
-		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
-		return
-	}
-	if prefix != s.pathPrefix {
-		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
 		return
 	}
 
-	switch method {
-	case "Put":
-		s.servePut(ctx, resp, req)
-		return
-	case "Get":
-		s.serveGet(ctx, resp, req)
-		return
-	default:
-		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
-		return
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
 	}
+	callResponseSent(ctx, s.hooks)
 }
 
-func (s *eventReplicatorServiceServer) servePut(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *eventReplicatorServiceServer) serveUpdateRegions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -434,9 +2666,9 @@ func (s *eventReplicatorServiceServer) servePut(ctx context.Context, resp http.R
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.servePutJSON(ctx, resp, req)
+		s.serveUpdateRegionsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.servePutProtobuf(ctx, resp, req)
+		s.serveUpdateRegionsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -444,9 +2676,9 @@ func (s *eventReplicatorServiceServer) servePut(ctx context.Context, resp http.R
 	}
 }
 
-func (s *eventReplicatorServiceServer) servePutJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *eventReplicatorServiceServer) serveUpdateRegionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Put")
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateRegions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -459,29 +2691,29 @@ func (s *eventReplicatorServiceServer) servePutJSON(ctx context.Context, resp ht
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(PutEventRequest)
+	reqContent := new(UpdateRegionsRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.EventReplicatorService.Put
+	handler := s.EventReplicatorService.UpdateRegions
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *PutEventRequest) (*Event, error) {
+		handler = func(ctx context.Context, req *UpdateRegionsRequest) (*RegionRegistry, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*PutEventRequest)
+					typedReq, ok := req.(*UpdateRegionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*PutEventRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*UpdateRegionsRequest) when calling interceptor")
 					}
-					return s.EventReplicatorService.Put(ctx, typedReq)
+					return s.EventReplicatorService.UpdateRegions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*Event)
+				typedResp, ok := resp.(*RegionRegistry)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -490,7 +2722,7 @@ func (s *eventReplicatorServiceServer) servePutJSON(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *Event
+	var respContent *RegionRegistry
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -501,7 +2733,7 @@ func (s *eventReplicatorServiceServer) servePutJSON(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Put. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RegionRegistry and nil error while calling UpdateRegions. nil responses are not supported"))
 		return
 	}
 
@@ -527,9 +2759,9 @@ func (s *eventReplicatorServiceServer) servePutJSON(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *eventReplicatorServiceServer) servePutProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *eventReplicatorServiceServer) serveUpdateRegionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Put")
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateRegions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -541,28 +2773,28 @@ func (s *eventReplicatorServiceServer) servePutProtobuf(ctx context.Context, res
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(PutEventRequest)
+	reqContent := new(UpdateRegionsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.EventReplicatorService.Put
+	handler := s.EventReplicatorService.UpdateRegions
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *PutEventRequest) (*Event, error) {
+		handler = func(ctx context.Context, req *UpdateRegionsRequest) (*RegionRegistry, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*PutEventRequest)
+					typedReq, ok := req.(*UpdateRegionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*PutEventRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*UpdateRegionsRequest) when calling interceptor")
 					}
-					return s.EventReplicatorService.Put(ctx, typedReq)
+					return s.EventReplicatorService.UpdateRegions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*Event)
+				typedResp, ok := resp.(*RegionRegistry)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*RegionRegistry) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -571,7 +2803,7 @@ func (s *eventReplicatorServiceServer) servePutProtobuf(ctx context.Context, res
 	}
 
 	// Call service method
-	var respContent *Event
+	var respContent *RegionRegistry
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -582,7 +2814,7 @@ func (s *eventReplicatorServiceServer) servePutProtobuf(ctx context.Context, res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Put. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RegionRegistry and nil error while calling UpdateRegions. nil responses are not supported"))
 		return
 	}
 
@@ -606,7 +2838,7 @@ func (s *eventReplicatorServiceServer) servePutProtobuf(ctx context.Context, res
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *eventReplicatorServiceServer) serveGet(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *eventReplicatorServiceServer) serveListQuarantined(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -614,9 +2846,9 @@ func (s *eventReplicatorServiceServer) serveGet(ctx context.Context, resp http.R
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveGetJSON(ctx, resp, req)
+		s.serveListQuarantinedJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveGetProtobuf(ctx, resp, req)
+		s.serveListQuarantinedProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -624,9 +2856,9 @@ func (s *eventReplicatorServiceServer) serveGet(ctx context.Context, resp http.R
 	}
 }
 
-func (s *eventReplicatorServiceServer) serveGetJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *eventReplicatorServiceServer) serveListQuarantinedJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Get")
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuarantined")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -639,29 +2871,29 @@ func (s *eventReplicatorServiceServer) serveGetJSON(ctx context.Context, resp ht
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(GetEventRequest)
+	reqContent := new(ListQuarantinedRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.EventReplicatorService.Get
+	handler := s.EventReplicatorService.ListQuarantined
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *GetEventRequest) (*Event, error) {
+		handler = func(ctx context.Context, req *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*GetEventRequest)
+					typedReq, ok := req.(*ListQuarantinedRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*GetEventRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuarantinedRequest) when calling interceptor")
 					}
-					return s.EventReplicatorService.Get(ctx, typedReq)
+					return s.EventReplicatorService.ListQuarantined(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*Event)
+				typedResp, ok := resp.(*ListQuarantinedResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuarantinedResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -670,7 +2902,7 @@ func (s *eventReplicatorServiceServer) serveGetJSON(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *Event
+	var respContent *ListQuarantinedResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -681,7 +2913,7 @@ func (s *eventReplicatorServiceServer) serveGetJSON(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Get. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListQuarantinedResponse and nil error while calling ListQuarantined. nil responses are not supported"))
 		return
 	}
 
@@ -707,9 +2939,9 @@ func (s *eventReplicatorServiceServer) serveGetJSON(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *eventReplicatorServiceServer) serveGetProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *eventReplicatorServiceServer) serveListQuarantinedProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Get")
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuarantined")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -721,28 +2953,28 @@ func (s *eventReplicatorServiceServer) serveGetProtobuf(ctx context.Context, res
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(GetEventRequest)
+	reqContent := new(ListQuarantinedRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.EventReplicatorService.Get
+	handler := s.EventReplicatorService.ListQuarantined
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *GetEventRequest) (*Event, error) {
+		handler = func(ctx context.Context, req *ListQuarantinedRequest) (*ListQuarantinedResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*GetEventRequest)
+					typedReq, ok := req.(*ListQuarantinedRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*GetEventRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuarantinedRequest) when calling interceptor")
 					}
-					return s.EventReplicatorService.Get(ctx, typedReq)
+					return s.EventReplicatorService.ListQuarantined(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*Event)
+				typedResp, ok := resp.(*ListQuarantinedResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*Event) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuarantinedResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -751,7 +2983,7 @@ func (s *eventReplicatorServiceServer) serveGetProtobuf(ctx context.Context, res
 	}
 
 	// Call service method
-	var respContent *Event
+	var respContent *ListQuarantinedResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -762,7 +2994,7 @@ func (s *eventReplicatorServiceServer) serveGetProtobuf(ctx context.Context, res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Event and nil error while calling Get. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListQuarantinedResponse and nil error while calling ListQuarantined. nil responses are not supported"))
 		return
 	}
 
@@ -924,9 +3156,12 @@ func sanitizeBaseURL(baseURL string) string {
 
 // baseServicePath composes the path prefix for the service (without <Method>).
 // e.g.: baseServicePath("/twirp", "my.pkg", "MyService")
-//       returns => "/twirp/my.pkg.MyService/"
+//
+//	returns => "/twirp/my.pkg.MyService/"
+//
 // e.g.: baseServicePath("", "", "MyService")
-//       returns => "/MyService/"
+//
+//	returns => "/MyService/"
 func baseServicePath(prefix, pkg, service string) string {
 	fullServiceName := service
 	if pkg != "" {
@@ -1364,30 +3599,98 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 394 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcb, 0x8e, 0xd3, 0x30,
-	0x14, 0x86, 0xe5, 0x5c, 0x5a, 0x38, 0x29, 0xb4, 0x58, 0x55, 0x15, 0xc1, 0x82, 0x36, 0x20, 0xd4,
-	0x55, 0x2a, 0x15, 0xfa, 0x00, 0xdc, 0xd4, 0x15, 0xa8, 0x32, 0x3b, 0x36, 0x95, 0x49, 0x8e, 0x90,
-	0x45, 0x13, 0x07, 0xdb, 0x89, 0xd4, 0x17, 0xe0, 0x65, 0x78, 0x81, 0x79, 0xbc, 0x51, 0xec, 0x54,
-	0xbd, 0x4c, 0x35, 0x9a, 0xd9, 0xf9, 0x9c, 0xdf, 0xff, 0xc9, 0xef, 0xcf, 0x0e, 0x8c, 0x2b, 0x25,
-	0x8d, 0xd4, 0x0b, 0x8d, 0xaa, 0x11, 0x19, 0xa6, 0xb6, 0xa4, 0xa0, 0xb0, 0xda, 0x89, 0x8c, 0x1b,
-	0xa9, 0x92, 0x1b, 0x02, 0xc3, 0x4d, 0x6d, 0xbe, 0x36, 0x58, 0x1a, 0x86, 0x7f, 0x6b, 0xd4, 0x86,
-	0x3e, 0x07, 0x4f, 0xe4, 0x31, 0x99, 0x92, 0xf9, 0x53, 0xe6, 0x89, 0x9c, 0xbe, 0x86, 0x88, 0x67,
-	0x46, 0xc8, 0x72, 0x5b, 0xf2, 0x02, 0x63, 0xcf, 0x0a, 0xe0, 0x5a, 0xdf, 0x79, 0x81, 0x74, 0x06,
-	0x83, 0xee, 0x0b, 0xdb, 0x4c, 0xe6, 0x18, 0xfb, 0x76, 0x47, 0xd4, 0xf5, 0x3e, 0xcb, 0x1c, 0xe9,
-	0x1b, 0x78, 0xa6, 0x65, 0xad, 0x32, 0xdc, 0x2a, 0xfc, 0x2d, 0x64, 0x19, 0x07, 0x53, 0x32, 0x0f,
-	0xd9, 0xc0, 0x35, 0x99, 0xed, 0x51, 0x0a, 0x41, 0xce, 0x0d, 0x8f, 0x43, 0xeb, 0xb7, 0x6b, 0x1a,
-	0x43, 0xbf, 0x41, 0xa5, 0x5b, 0x4b, 0xcf, 0x5a, 0x0e, 0x65, 0x32, 0x83, 0xe1, 0x1a, 0xef, 0x4d,
-	0x9e, 0x28, 0x08, 0xad, 0xfe, 0xf8, 0x23, 0x5d, 0x8b, 0xf2, 0x16, 0x82, 0x02, 0x0d, 0xb7, 0x39,
-	0xa2, 0xe5, 0x28, 0x3d, 0x62, 0x4c, 0xbf, 0xa1, 0xe1, 0xcc, 0xaa, 0xc9, 0x7f, 0x02, 0x41, 0x5b,
-	0xde, 0xa1, 0x42, 0x1e, 0x40, 0xc5, 0xbb, 0x42, 0xe5, 0x84, 0x80, 0x7f, 0x46, 0x80, 0x7e, 0x84,
-	0x51, 0x26, 0x8b, 0x42, 0x18, 0xcc, 0xbb, 0x01, 0xda, 0x72, 0x8d, 0x96, 0x93, 0xd3, 0x70, 0x5f,
-	0x84, 0x3d, 0x17, 0x57, 0x7b, 0x36, 0x3c, 0xec, 0x77, 0xb3, 0x75, 0x92, 0x42, 0xb0, 0xe1, 0x42,
-	0xd1, 0x11, 0xf8, 0x7f, 0x70, 0x6f, 0x33, 0x86, 0xac, 0x5d, 0xd2, 0x31, 0x84, 0x0d, 0xdf, 0xd5,
-	0x0e, 0xce, 0x13, 0xe6, 0x8a, 0xe4, 0x03, 0xc0, 0x71, 0x1c, 0x7d, 0x07, 0x61, 0xc5, 0x85, 0xd2,
-	0x31, 0x99, 0xfa, 0x97, 0x48, 0xda, 0xb1, 0xcc, 0xc9, 0xcb, 0x7f, 0x04, 0x26, 0xdd, 0x45, 0x1d,
-	0xf4, 0x1f, 0x0e, 0x03, 0x5d, 0x81, 0xbf, 0xa9, 0x0d, 0x7d, 0x75, 0x66, 0x3d, 0x7f, 0x90, 0x2f,
-	0x5f, 0x9c, 0x8a, 0xee, 0x42, 0x57, 0xe0, 0xaf, 0xf1, 0xc2, 0x76, 0xf1, 0x1a, 0xae, 0xd8, 0x3e,
-	0xf5, 0x7f, 0x86, 0xe9, 0x42, 0x55, 0xd9, 0xaf, 0x9e, 0xfd, 0x15, 0xde, 0xdf, 0x06, 0x00, 0x00,
-	0xff, 0xff, 0x49, 0xf1, 0xd5, 0xa4, 0x22, 0x03, 0x00, 0x00,
+	// 1475 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x4b, 0x73, 0xdc, 0xc4,
+	0x13, 0xaf, 0x7d, 0x68, 0x1f, 0xbd, 0x7e, 0x8e, 0xfd, 0x77, 0x54, 0x9b, 0x7f, 0x12, 0x47, 0x09,
+	0x89, 0x43, 0x05, 0xa7, 0x70, 0xa0, 0x8a, 0x22, 0x50, 0x94, 0xf3, 0x28, 0x17, 0x84, 0x04, 0x23,
+	0x87, 0x1c, 0x72, 0x40, 0x35, 0x96, 0xda, 0xae, 0x29, 0x6b, 0x35, 0x42, 0x33, 0x6b, 0xec, 0x1c,
+	0xb8, 0x70, 0xe3, 0x13, 0xf1, 0x29, 0x28, 0x0e, 0x7c, 0x08, 0x6e, 0x1c, 0xb8, 0x72, 0xa0, 0xe6,
+	0xb5, 0x2b, 0x59, 0x6b, 0x27, 0x39, 0x72, 0x53, 0x3f, 0xa6, 0xa7, 0xfb, 0xd7, 0x3d, 0xdd, 0x2d,
+	0x58, 0xcd, 0x0b, 0x2e, 0xb9, 0xb8, 0x27, 0xb0, 0x38, 0x66, 0x31, 0x6e, 0x6a, 0x92, 0x40, 0x81,
+	0x79, 0xca, 0x62, 0x2a, 0x79, 0x11, 0xfc, 0xda, 0x80, 0xc5, 0xdd, 0xb1, 0x7c, 0x72, 0x8c, 0x99,
+	0x0c, 0xf1, 0x87, 0x31, 0x0a, 0x49, 0x16, 0xa0, 0xc9, 0x12, 0xbf, 0xb1, 0xde, 0xd8, 0xe8, 0x87,
+	0x4d, 0x96, 0x90, 0x6b, 0x30, 0xa0, 0xb1, 0x64, 0x3c, 0x8b, 0x32, 0x3a, 0x42, 0xbf, 0xa9, 0x05,
+	0x60, 0x58, 0xcf, 0xe9, 0x08, 0xc9, 0x75, 0x98, 0xb3, 0x37, 0x44, 0x31, 0x4f, 0xd0, 0x6f, 0x69,
+	0x8d, 0x81, 0xe5, 0x3d, 0xe2, 0x09, 0x92, 0x1b, 0x30, 0x2f, 0xf8, 0xb8, 0x88, 0x31, 0x2a, 0xf0,
+	0x90, 0xf1, 0xcc, 0x6f, 0xaf, 0x37, 0x36, 0xbc, 0x70, 0xce, 0x30, 0x43, 0xcd, 0x23, 0x04, 0xda,
+	0x09, 0x95, 0xd4, 0xf7, 0xf4, 0x79, 0xfd, 0x4d, 0x7c, 0xe8, 0x1e, 0x63, 0x21, 0xd4, 0x91, 0x8e,
+	0x3e, 0xe2, 0xc8, 0xe0, 0x3a, 0x2c, 0xee, 0xe0, 0x85, 0x9e, 0x07, 0x31, 0x90, 0xc7, 0x98, 0xa2,
+	0xc4, 0x0b, 0xe3, 0xab, 0xf9, 0xd6, 0x9c, 0xe1, 0x5b, 0xc9, 0x8f, 0x56, 0xd5, 0x8f, 0xbf, 0x1a,
+	0xb0, 0x12, 0xa2, 0xe0, 0xe9, 0x31, 0xfe, 0x07, 0x61, 0x24, 0x1f, 0x42, 0x37, 0xe6, 0x99, 0xc4,
+	0x13, 0xe9, 0x77, 0xd7, 0x5b, 0x1b, 0x83, 0xad, 0x4b, 0x9b, 0xd3, 0xfa, 0xd8, 0x7c, 0x89, 0xb1,
+	0xe4, 0xc5, 0x93, 0x4c, 0x16, 0xa7, 0xa1, 0xd3, 0x0b, 0x7e, 0x6f, 0xc0, 0xf2, 0xd7, 0x4c, 0x18,
+	0xec, 0x85, 0x8b, 0x77, 0x0d, 0x3a, 0x79, 0x81, 0x07, 0xec, 0xc4, 0xc6, 0x6c, 0xa9, 0x5a, 0x58,
+	0xcd, 0xb7, 0x08, 0xab, 0x35, 0x23, 0xac, 0x33, 0xf8, 0xb5, 0x6b, 0xf8, 0x5d, 0x86, 0x7e, 0x4e,
+	0x0f, 0x31, 0x12, 0xec, 0x35, 0xea, 0xe0, 0xbd, 0xb0, 0xa7, 0x18, 0x7b, 0xec, 0x35, 0x92, 0x2b,
+	0x00, 0x5a, 0x28, 0xf9, 0x11, 0x1a, 0x0c, 0xfa, 0xa1, 0x56, 0x7f, 0xa1, 0x18, 0xc1, 0x21, 0x90,
+	0x72, 0x44, 0x22, 0xe7, 0x99, 0x40, 0x72, 0x07, 0x3a, 0xa8, 0x39, 0x7e, 0x43, 0x43, 0xb3, 0x5c,
+	0x86, 0xc6, 0x24, 0xdb, 0x2a, 0x90, 0x5b, 0xb0, 0x98, 0xe1, 0x89, 0x8c, 0x4a, 0x97, 0x98, 0x40,
+	0xe7, 0x15, 0x7b, 0x77, 0x72, 0x91, 0x80, 0xcb, 0x3b, 0x28, 0x43, 0x6b, 0x86, 0xf1, 0x6c, 0x4f,
+	0x52, 0x39, 0x9e, 0x80, 0xb8, 0x09, 0x2b, 0x42, 0x8e, 0xe3, 0xa3, 0x88, 0x1e, 0x48, 0x2c, 0x22,
+	0x81, 0x31, 0xcf, 0x12, 0xa1, 0x11, 0x6d, 0x85, 0xcb, 0x5a, 0xb4, 0xad, 0x24, 0x7b, 0x46, 0x40,
+	0x6e, 0xc2, 0xc2, 0x88, 0x9e, 0x44, 0xe6, 0xcc, 0x11, 0x9e, 0x0a, 0x57, 0xbc, 0x23, 0x7a, 0xb2,
+	0xa7, 0x98, 0x4f, 0xf1, 0x54, 0x04, 0x7f, 0x36, 0x61, 0xb9, 0x76, 0xa5, 0xc2, 0xcb, 0xc0, 0x1d,
+	0xd9, 0x3a, 0xf5, 0xc2, 0x9e, 0x61, 0x7c, 0x99, 0x90, 0xf7, 0x61, 0x39, 0x1b, 0x8f, 0xf6, 0xb1,
+	0x88, 0xf8, 0x81, 0xcd, 0x8a, 0xb3, 0xbd, 0x68, 0x04, 0xdf, 0x1c, 0x98, 0xc4, 0x08, 0x95, 0x61,
+	0xc9, 0x25, 0x4d, 0x23, 0x0b, 0x56, 0x4b, 0x7b, 0x3b, 0xd0, 0x3c, 0x83, 0x28, 0x79, 0x0f, 0x16,
+	0x72, 0xcc, 0x12, 0x96, 0x1d, 0x3a, 0xa5, 0xb6, 0x56, 0x9a, 0xb7, 0x5c, 0xab, 0xf6, 0x00, 0x86,
+	0x3c, 0x4d, 0x50, 0xc8, 0xc8, 0x69, 0xeb, 0x84, 0x5a, 0x14, 0x3c, 0x7d, 0xe4, 0x92, 0xd1, 0xd8,
+	0x35, 0x0a, 0xdb, 0x87, 0xe8, 0xb0, 0xd8, 0x82, 0xae, 0x73, 0xb4, 0xa3, 0xd3, 0xe5, 0x97, 0xd3,
+	0x65, 0x9c, 0xb5, 0x68, 0x77, 0x8b, 0xa9, 0xeb, 0x06, 0x3b, 0xeb, 0x55, 0xd7, 0xb8, 0xae, 0x79,
+	0xd6, 0xa7, 0xbb, 0xe0, 0x69, 0xd2, 0xef, 0x69, 0xa3, 0x6b, 0x65, 0xa3, 0x7b, 0x13, 0xbd, 0xd0,
+	0x28, 0x05, 0xbf, 0x34, 0x60, 0xae, 0x7c, 0x95, 0x7a, 0x16, 0xb6, 0xa8, 0x0d, 0xc4, 0x96, 0x9a,
+	0x81, 0x48, 0xf3, 0xdd, 0x11, 0x69, 0x5d, 0x88, 0x48, 0x70, 0x00, 0x30, 0xf5, 0x70, 0x66, 0x43,
+	0x2a, 0xd9, 0x32, 0xd7, 0x03, 0x9d, 0x02, 0x7a, 0x1b, 0x16, 0x47, 0x4c, 0x08, 0x75, 0xa9, 0x03,
+	0xb6, 0xb5, 0xde, 0xda, 0xf0, 0xc2, 0x05, 0xcb, 0xb6, 0x05, 0x10, 0xac, 0x9a, 0xd7, 0xf3, 0x0c,
+	0x55, 0x5d, 0xb8, 0x5a, 0x0e, 0x1e, 0xc1, 0x4a, 0x85, 0x6b, 0x1f, 0xd5, 0x5d, 0xe8, 0x8e, 0x0c,
+	0xcb, 0xbe, 0x2a, 0x52, 0x46, 0xd4, 0x68, 0x87, 0x4e, 0x25, 0xf8, 0xa7, 0x09, 0x1d, 0xc3, 0x53,
+	0x7d, 0x4d, 0xbf, 0x7c, 0x13, 0x81, 0xfe, 0x56, 0x7d, 0x8d, 0x26, 0x49, 0x81, 0x42, 0xd8, 0xe7,
+	0xe6, 0x48, 0xb2, 0xaa, 0xd2, 0x46, 0xa5, 0x6b, 0xa3, 0x86, 0x28, 0x65, 0xa3, 0x5d, 0xc9, 0xc6,
+	0x75, 0x98, 0xa3, 0x39, 0x8b, 0x30, 0x4b, 0x72, 0xce, 0x32, 0x69, 0x7b, 0xe7, 0x80, 0xe6, 0xec,
+	0x89, 0x65, 0x91, 0xcf, 0xa0, 0x37, 0x42, 0x49, 0x75, 0x6b, 0x35, 0xf5, 0xb5, 0x5e, 0x77, 0x7c,
+	0xf3, 0x99, 0x55, 0x31, 0x2d, 0x73, 0x72, 0x42, 0xb9, 0x93, 0xf2, 0x98, 0xa6, 0xba, 0xc2, 0x7a,
+	0xa1, 0x21, 0x54, 0x48, 0x05, 0x4f, 0xd1, 0xef, 0x99, 0x90, 0xd4, 0xb7, 0x6a, 0x86, 0xfb, 0x63,
+	0x96, 0x26, 0x91, 0x6b, 0xd8, 0x7d, 0x2d, 0x9c, 0xd3, 0xcc, 0x97, 0x86, 0x47, 0xee, 0xc0, 0x92,
+	0x1e, 0xe6, 0x31, 0x4f, 0x27, 0x7a, 0x60, 0x5e, 0xa7, 0xe3, 0x5b, 0xd5, 0xe1, 0x03, 0x98, 0xaf,
+	0x38, 0x45, 0x96, 0xa0, 0x75, 0x84, 0xa7, 0x16, 0x46, 0xf5, 0xa9, 0x9c, 0x3b, 0xa6, 0xe9, 0xd8,
+	0xf5, 0x66, 0x43, 0x7c, 0xda, 0xfc, 0xa4, 0x11, 0xac, 0xc0, 0xb2, 0x6e, 0x57, 0x3a, 0xcf, 0x2e,
+	0xb1, 0x5f, 0xc1, 0xea, 0x77, 0x79, 0x42, 0x25, 0x56, 0xf9, 0x64, 0x08, 0xba, 0x7f, 0x08, 0x89,
+	0x85, 0x4e, 0xad, 0x17, 0x4e, 0x68, 0x03, 0xbc, 0x64, 0x85, 0xba, 0xa3, 0x65, 0x80, 0x57, 0x54,
+	0xf0, 0x13, 0x2c, 0x18, 0x2b, 0xa1, 0xd6, 0x2c, 0x4e, 0xcb, 0xa3, 0x4a, 0xb9, 0xd8, 0x9e, 0x8e,
+	0xaa, 0xab, 0x00, 0xce, 0x1e, 0x26, 0xd6, 0x4e, 0x89, 0xa3, 0x4e, 0x1a, 0xab, 0x89, 0xad, 0x53,
+	0x47, 0x1a, 0x89, 0xa9, 0xe0, 0xb6, 0x93, 0x98, 0xd2, 0xf5, 0x61, 0x4d, 0x15, 0xe9, 0xb7, 0x63,
+	0x5a, 0xd0, 0x4c, 0xb2, 0x0c, 0x13, 0x17, 0x65, 0x0c, 0x97, 0x6a, 0x12, 0x5b, 0xc2, 0xab, 0xe0,
+	0xe9, 0xe6, 0x66, 0x1d, 0x34, 0x04, 0xd9, 0x82, 0x5e, 0x4e, 0x4f, 0x53, 0x4e, 0xf5, 0x63, 0xaa,
+	0xf5, 0x8a, 0x87, 0x34, 0xd9, 0x35, 0xe2, 0x70, 0xa2, 0x17, 0xfc, 0xdc, 0x00, 0x98, 0x0a, 0x54,
+	0x3d, 0x1c, 0xb1, 0xcc, 0x3d, 0x52, 0xfd, 0xed, 0xd2, 0xd5, 0x9c, 0xa6, 0xcb, 0x87, 0xae, 0x35,
+	0xa0, 0x8b, 0x7b, 0x2e, 0x74, 0xa4, 0x72, 0x0c, 0x8b, 0x82, 0x17, 0x76, 0x3a, 0x1a, 0x42, 0x3d,
+	0xf4, 0x02, 0x63, 0x64, 0xc7, 0x98, 0x44, 0x54, 0xda, 0x36, 0x0a, 0x8e, 0xb5, 0x2d, 0x83, 0x3f,
+	0x1a, 0xe0, 0x9d, 0xdf, 0x23, 0x2e, 0x5c, 0x5a, 0x66, 0x2d, 0x1b, 0x37, 0xa1, 0xad, 0xea, 0x5e,
+	0x4f, 0xd9, 0xc1, 0xd6, 0x52, 0xf5, 0x95, 0x48, 0x1a, 0x6a, 0x29, 0xf9, 0x00, 0x7a, 0x82, 0xed,
+	0xa7, 0x2c, 0x3b, 0x14, 0x7e, 0xf7, 0xbc, 0xf1, 0x3a, 0x51, 0x29, 0xef, 0x29, 0xbd, 0xb7, 0xdc,
+	0x53, 0x7e, 0x6b, 0x42, 0x5b, 0x5d, 0x58, 0x5b, 0x41, 0x1a, 0x6f, 0xb1, 0x82, 0xbc, 0xd3, 0x12,
+	0x48, 0xb6, 0x61, 0x29, 0xe6, 0xa3, 0x11, 0x93, 0x98, 0x44, 0xd3, 0x4a, 0x6b, 0x9c, 0xad, 0x81,
+	0xc7, 0x4c, 0x23, 0x47, 0x8b, 0xd3, 0x70, 0xd1, 0xe9, 0xbb, 0x29, 0xfa, 0x7f, 0xe8, 0x4b, 0x3e,
+	0xda, 0x17, 0x92, 0x67, 0x66, 0x7d, 0xe9, 0x85, 0x53, 0x86, 0xda, 0x5f, 0x12, 0xbd, 0xca, 0xea,
+	0x14, 0x76, 0x74, 0x0a, 0xfb, 0x96, 0xb3, 0x2d, 0xc9, 0x7d, 0xe8, 0x4b, 0x36, 0x42, 0x21, 0xe9,
+	0x28, 0xd7, 0x2d, 0x66, 0xb0, 0xf5, 0xbf, 0xf2, 0xc5, 0x2f, 0x9c, 0x30, 0x9c, 0xea, 0x91, 0x7b,
+	0xd0, 0x39, 0xd6, 0xb8, 0xbd, 0x09, 0x51, 0xab, 0x16, 0xbc, 0x82, 0xfe, 0xc4, 0x90, 0x5a, 0x1f,
+	0x7e, 0xa4, 0x69, 0x1a, 0x29, 0x7b, 0x76, 0x41, 0xe9, 0x29, 0x86, 0xd2, 0x50, 0x48, 0xa5, 0xfc,
+	0x90, 0xa9, 0x86, 0xa7, 0x80, 0x9c, 0x0f, 0x1d, 0x59, 0xea, 0xc0, 0xad, 0x72, 0x07, 0x0e, 0xbe,
+	0x80, 0x41, 0xe9, 0xca, 0x73, 0xc7, 0xa6, 0xaf, 0xca, 0x60, 0x9c, 0x49, 0x2c, 0xb4, 0xe1, 0x76,
+	0xe8, 0xc8, 0x60, 0x13, 0xda, 0xbb, 0x94, 0x15, 0xe5, 0xf6, 0xe6, 0xcd, 0x68, 0x6f, 0x3d, 0xdb,
+	0xde, 0x82, 0x8f, 0x00, 0xa6, 0xe9, 0x20, 0xb7, 0xc0, 0xcb, 0x29, 0x9b, 0xcc, 0xa4, 0x4a, 0xd1,
+	0x2a, 0xb3, 0xa1, 0x11, 0x6f, 0xfd, 0xed, 0xc1, 0x9a, 0x5d, 0xf3, 0x9d, 0x7c, 0xcf, 0x94, 0x11,
+	0xf9, 0x18, 0x5a, 0xbb, 0x63, 0x49, 0x2e, 0x57, 0x8e, 0x56, 0xff, 0xad, 0x86, 0xf5, 0x12, 0x57,
+	0xc7, 0x76, 0xf0, 0xcc, 0xb1, 0x1d, 0x7c, 0xe3, 0xb1, 0x07, 0xd0, 0x31, 0xff, 0x36, 0xe4, 0x6a,
+	0xa5, 0xc2, 0x6a, 0xff, 0x3b, 0xb3, 0x0e, 0x3f, 0x05, 0x98, 0xae, 0xbb, 0xe4, 0x4a, 0x59, 0xa1,
+	0xb6, 0xd8, 0x0f, 0xaf, 0x9e, 0x27, 0xb6, 0xdd, 0xf0, 0x73, 0xe8, 0xda, 0xff, 0x1f, 0x72, 0xad,
+	0xba, 0x71, 0xd5, 0x7e, 0x8a, 0x66, 0xf9, 0xf2, 0x3d, 0xac, 0xce, 0xda, 0x88, 0xc9, 0xed, 0x33,
+	0x80, 0x9c, 0xb7, 0x33, 0x0f, 0xaf, 0x54, 0x2f, 0x3d, 0x6b, 0xe7, 0x39, 0x0c, 0x4a, 0x6b, 0x08,
+	0xa9, 0x45, 0x53, 0xdd, 0x5a, 0x86, 0xd7, 0xce, 0x95, 0xdb, 0x70, 0x77, 0x00, 0xa6, 0x23, 0xb1,
+	0x8a, 0x5d, 0x6d, 0x54, 0x0e, 0x87, 0xf5, 0x15, 0x74, 0x32, 0xe8, 0x9e, 0xc1, 0x7c, 0x65, 0x8c,
+	0x92, 0xca, 0x3e, 0x31, 0x6b, 0xc2, 0x5e, 0x68, 0xee, 0x15, 0x2c, 0x9e, 0x99, 0x57, 0x24, 0x38,
+	0x1b, 0x4b, 0x7d, 0xcc, 0x0d, 0x6f, 0x5c, 0xa8, 0x63, 0x62, 0x7e, 0xd8, 0x7d, 0xe5, 0x6d, 0xde,
+	0x2b, 0xf2, 0x78, 0xbf, 0xa3, 0xb7, 0x8b, 0xfb, 0xff, 0x0e, 0x00, 0x80, 0x9e, 0xa7, 0xbb, 0x5a,
+	0x10, 0x00, 0x00,
 }